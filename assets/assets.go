@@ -0,0 +1,32 @@
+/*
+	Package assets loads the images the game and the editor draw with, so both cut up spritesheets the same way.
+*/
+package assets
+
+import (
+	"github.com/faiface/pixel"
+	"image"
+	_ "image/png"
+	"io/fs"
+)
+
+/*
+	Loads a basic Go picture as a pixel picture
+*/
+func LoadPicture(path string) (pixel.Picture, error) {
+	file, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file fs.File) {
+		err := file.Close()
+		if err != nil {
+			print("failed to load sprite with the path " + path)
+		}
+	}(file)
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	return pixel.PictureDataFromImage(img), nil
+}
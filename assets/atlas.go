@@ -0,0 +1,267 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/faiface/pixel"
+	"image"
+	"os"
+	"strings"
+)
+
+const defaultFrameTime = 1.0 / 12 //seconds a frame shows for when its animation doesn't say
+
+/*
+	Atlas is a spritesheet along with everything its sidecar file says about it: where each frame is, which point
+	of each frame sits on the entity's position, and which frames make up each animation
+*/
+type Atlas struct {
+	Pic        pixel.Picture        //nil if the atlas was only read, not loaded
+	Frames     []Frame              //every frame on the sheet
+	Sprites    []*pixel.Sprite      //a sprite for each of Frames, made once when the atlas is loaded so drawing doesn't allocate
+	Animations map[string]Animation //animations by name, e.g. "run_NW"
+}
+
+/*
+	Frame is one picture on a spritesheet
+*/
+type Frame struct {
+	Rect  pixel.Rect
+	Pivot pixel.Vec //the point of the frame drawn on the entity's position, from the frame's bottom left corner
+}
+
+/*
+	Animation is a list of frames played one after the other
+*/
+type Animation struct {
+	Frames    []int     //indexes into the atlas's Frames, in the order they're played
+	Durations []float64 //seconds each frame shows for, one for each of Frames
+}
+
+/*
+	How far a sprite of this frame has to be moved so its pivot, rather than its center, lands on the origin
+*/
+func (f Frame) Offset() pixel.Vec {
+	return f.Rect.Size().Scaled(0.5).Sub(f.Pivot)
+}
+
+/*
+	The frame at index i and the sprite to draw it with. An atlas without that many frames, like a placeholder,
+	gives its first one instead.
+*/
+func (a *Atlas) Frame(i int) (Frame, *pixel.Sprite) {
+	if i < 0 || i >= len(a.Frames) {
+		i = 0
+	}
+	return a.Frames[i], a.Sprites[i]
+}
+
+/*
+	How long the whole animation takes to play once
+*/
+func (a Animation) Length() float64 {
+	total := 0.0
+	for _, d := range a.Durations {
+		total += d
+	}
+	return total
+}
+
+/*
+	sidecar is how an atlas is written in the JSON file next to its PNG. Coordinates are in pixels, measured from
+	the bottom left of the sheet like everything else in the game.
+*/
+type sidecar struct {
+	Grid       *gridMeta           `json:"grid"`       //cut the sheet into equal frames, left to right then bottom to top
+	Frames     []frameMeta         `json:"frames"`     //or list every frame by hand
+	Pivot      *pixel.Vec          `json:"pivot"`      //pivot of every frame that doesn't have its own, their centers if left out
+	Animations map[string]animMeta `json:"animations"` //animations by name
+}
+
+type gridMeta struct {
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+type frameMeta struct {
+	X     float64    `json:"x"`
+	Y     float64    `json:"y"`
+	W     float64    `json:"w"`
+	H     float64    `json:"h"`
+	Pivot *pixel.Vec `json:"pivot"`
+}
+
+/*
+	animMeta picks an animation's frames either as a row of the grid or as a list of frame indexes
+*/
+type animMeta struct {
+	Row       *int      `json:"row"`       //row of the grid, counting up from the bottom
+	Count     int       `json:"count"`     //how many frames of the row to play, all of them if left out
+	Frames    []int     `json:"frames"`    //frame indexes, instead of a row
+	Duration  float64   `json:"duration"`  //seconds every frame shows for
+	Durations []float64 `json:"durations"` //or seconds for each frame on its own
+}
+
+/*
+	Loads a spritesheet PNG and reads its sidecar file
+*/
+func LoadAtlas(path string) (*Atlas, error) {
+	atlas, err := ReadAtlas(path)
+	if err != nil {
+		return nil, err
+	}
+	atlas.Pic, err = LoadPicture(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, frame := range atlas.Frames {
+		atlas.Sprites = append(atlas.Sprites, pixel.NewSprite(atlas.Pic, frame.Rect))
+	}
+	return atlas, nil
+}
+
+/*
+	Reads a spritesheet's sidecar file without loading the picture itself, so the frames and animations can be used
+	without a window. The sidecar of "sprites/rings.png" is "sprites/rings.json". A sheet without one is a single
+	frame.
+*/
+func ReadAtlas(path string) (*Atlas, error) {
+	bounds, err := pictureBounds(path)
+	if err != nil {
+		return nil, err
+	}
+
+	metaPath := sidecarPath(path)
+	meta := sidecar{}
+	file, err := Open(metaPath) //open to read
+	if os.IsNotExist(err) {
+		return &Atlas{Frames: []Frame{{bounds, bounds.Size().Scaled(0.5)}}, Animations: map[string]Animation{}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&meta); err != nil {
+		return nil, fmt.Errorf("%s: %v", metaPath, err)
+	}
+
+	atlas, err := meta.build(bounds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", metaPath, err)
+	}
+	return atlas, nil
+}
+
+/*
+	Works out every frame and animation the sidecar describes on a sheet of the given size
+*/
+func (meta sidecar) build(bounds pixel.Rect) (*Atlas, error) {
+	atlas := &Atlas{Animations: map[string]Animation{}}
+	pivot := func(rect pixel.Rect, own *pixel.Vec) pixel.Vec {
+		if own != nil {
+			return *own
+		}
+		if meta.Pivot != nil {
+			return *meta.Pivot
+		}
+		return rect.Size().Scaled(0.5)
+	}
+
+	columns := 0
+	if meta.Grid != nil {
+		if meta.Grid.W <= 0 || meta.Grid.H <= 0 {
+			return nil, fmt.Errorf("grid frames need a width and height more than 0")
+		}
+		columns = int(bounds.W() / meta.Grid.W)
+		for y := bounds.Min.Y; y+meta.Grid.H <= bounds.Max.Y; y += meta.Grid.H {
+			for x := bounds.Min.X; x+meta.Grid.W <= bounds.Max.X; x += meta.Grid.W {
+				rect := pixel.R(x, y, x+meta.Grid.W, y+meta.Grid.H)
+				atlas.Frames = append(atlas.Frames, Frame{rect, pivot(rect, nil)})
+			}
+		}
+	}
+	for i, f := range meta.Frames {
+		rect := pixel.R(f.X, f.Y, f.X+f.W, f.Y+f.H)
+		if f.W <= 0 || f.H <= 0 || rect.Min.X < bounds.Min.X || rect.Min.Y < bounds.Min.Y ||
+			rect.Max.X > bounds.Max.X || rect.Max.Y > bounds.Max.Y {
+			return nil, fmt.Errorf("frame %d doesn't fit on the sheet", i)
+		}
+		atlas.Frames = append(atlas.Frames, Frame{rect, pivot(rect, f.Pivot)})
+	}
+	if len(atlas.Frames) == 0 {
+		return nil, fmt.Errorf("there are no frames, give it a grid or a list of frames")
+	}
+
+	for name, anim := range meta.Animations {
+		frames := anim.Frames
+		if anim.Row != nil {
+			if columns == 0 {
+				return nil, fmt.Errorf("animation %q uses a row, but there's no grid", name)
+			}
+			count := anim.Count
+			if count == 0 {
+				count = columns
+			}
+			if count > columns {
+				return nil, fmt.Errorf("animation %q has %d frames, but rows only have %d", name, count, columns)
+			}
+			frames = nil
+			for i := 0; i < count; i++ {
+				frames = append(frames, *anim.Row*columns+i)
+			}
+		}
+		if len(frames) == 0 {
+			return nil, fmt.Errorf("animation %q has no frames", name)
+		}
+		for _, f := range frames {
+			if f < 0 || f >= len(atlas.Frames) {
+				return nil, fmt.Errorf("animation %q uses frame %d, but there are only %d", name, f, len(atlas.Frames))
+			}
+		}
+
+		durations := anim.Durations
+		if durations == nil {
+			duration := anim.Duration
+			if duration == 0 {
+				duration = defaultFrameTime
+			}
+			for range frames {
+				durations = append(durations, duration)
+			}
+		}
+		if len(durations) != len(frames) {
+			return nil, fmt.Errorf("animation %q has %d frames but %d durations", name, len(frames), len(durations))
+		}
+		for _, d := range durations {
+			if d <= 0 {
+				return nil, fmt.Errorf("animation %q has a frame that doesn't last any time", name)
+			}
+		}
+		atlas.Animations[name] = Animation{frames, durations}
+	}
+	return atlas, nil
+}
+
+/*
+	Where the sidecar file of a spritesheet is, e.g. "sprites/rings.json" for "sprites/rings.png"
+*/
+func sidecarPath(path string) string {
+	return strings.TrimSuffix(path, ".png") + ".json"
+}
+
+/*
+	The size of a picture, read from its header so the whole thing doesn't need decoding
+*/
+func pictureBounds(path string) (pixel.Rect, error) {
+	file, err := Open(path)
+	if err != nil {
+		return pixel.Rect{}, err
+	}
+	defer file.Close()
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return pixel.Rect{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return pixel.R(0, 0, float64(config.Width), float64(config.Height)), nil
+}
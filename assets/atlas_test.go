@@ -0,0 +1,135 @@
+package assets
+
+import (
+	"encoding/json"
+	"github.com/faiface/pixel"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+	Works out the atlas a sidecar file describes, on a sheet 128 wide and 32 high
+*/
+func buildSidecar(t *testing.T, data string) (*Atlas, error) {
+	meta := sidecar{}
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&meta); err != nil {
+		t.Fatal(err)
+	}
+	return meta.build(pixel.R(0, 0, 128, 32))
+}
+
+/*
+	A grid is cut left to right then bottom to top, and a row of it counts up from the bottom
+*/
+func TestBuildGrid(t *testing.T) {
+	atlas, err := buildSidecar(t, `{"grid": {"w": 32, "h": 16}, "animations": {
+		"walk": {"row": 1, "count": 3, "duration": 0.25},
+		"stand": {"row": 0},
+		"blink": {"frames": [7, 0, 7], "durations": [0.5, 0.125, 0.5]}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(atlas.Frames) != 8 {
+		t.Fatalf("cut %d frames, want 8", len(atlas.Frames))
+	}
+	for _, frame := range []struct {
+		index int
+		rect  pixel.Rect
+	}{{0, pixel.R(0, 0, 32, 16)}, {3, pixel.R(96, 0, 128, 16)}, {4, pixel.R(0, 16, 32, 32)}} {
+		if got := atlas.Frames[frame.index]; got.Rect != frame.rect || got.Pivot != pixel.V(16, 8) {
+			t.Errorf("frame %d is %v pivoted on %v, want %v pivoted on its center", frame.index, got.Rect, got.Pivot, frame.rect)
+		}
+	}
+	tests := []struct {
+		name string
+		want Animation
+	}{
+		{"walk", Animation{[]int{4, 5, 6}, []float64{0.25, 0.25, 0.25}}},
+		{"stand", Animation{[]int{0, 1, 2, 3}, []float64{defaultFrameTime, defaultFrameTime, defaultFrameTime, defaultFrameTime}}},
+		{"blink", Animation{[]int{7, 0, 7}, []float64{0.5, 0.125, 0.5}}},
+	}
+	for _, test := range tests {
+		if got := atlas.Animations[test.name]; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s is %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+/*
+	A frame's pivot is its own if it has one, the sheet's if not, and its center if neither says, and Offset moves
+	the frame's center that far from the pivot
+*/
+func TestBuildPivots(t *testing.T) {
+	atlas, err := buildSidecar(t, `{"pivot": {"X": 4, "Y": 2}, "frames": [
+		{"x": 0, "y": 0, "w": 20, "h": 10},
+		{"x": 20, "y": 0, "w": 30, "h": 30, "pivot": {"X": 15, "Y": 0}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noPivot, err := buildSidecar(t, `{"frames": [{"x": 100, "y": 8, "w": 28, "h": 24}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		frame  Frame
+		rect   pixel.Rect
+		pivot  pixel.Vec
+		offset pixel.Vec //half the size, less the pivot
+	}{
+		{atlas.Frames[0], pixel.R(0, 0, 20, 10), pixel.V(4, 2), pixel.V(6, 3)},
+		{atlas.Frames[1], pixel.R(20, 0, 50, 30), pixel.V(15, 0), pixel.V(0, 15)},
+		{noPivot.Frames[0], pixel.R(100, 8, 128, 32), pixel.V(14, 12), pixel.ZV},
+	}
+	for i, test := range tests {
+		if test.frame.Rect != test.rect || test.frame.Pivot != test.pivot {
+			t.Errorf("frame %d is %v pivoted on %v, want %v pivoted on %v", i, test.frame.Rect, test.frame.Pivot, test.rect,
+				test.pivot)
+		}
+		if got := test.frame.Offset(); got != test.offset {
+			t.Errorf("frame %d has offset %v, want %v", i, got, test.offset)
+		}
+	}
+}
+
+/*
+	Anything in a sidecar that can't be cut from the sheet or played is an error saying what's wrong
+*/
+func TestBuildErrors(t *testing.T) {
+	grid := `"grid": {"w": 32, "h": 16}`
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"no frames", `{}`, "there are no frames, give it a grid or a list of frames"},
+		{"empty grid", `{"grid": {"w": 0, "h": 16}}`, "grid frames need a width and height more than 0"},
+		{"frame off the sheet", `{"frames": [{"x": 100, "y": 0, "w": 30, "h": 10}]}`, "frame 0 doesn't fit on the sheet"},
+		{"row without a grid", `{"frames": [{"x": 0, "y": 0, "w": 8, "h": 8}], "animations": {"a": {"row": 0}}}`,
+			`animation "a" uses a row, but there's no grid`},
+		{"count longer than a row", `{` + grid + `, "animations": {"a": {"row": 0, "count": 5}}}`,
+			`animation "a" has 5 frames, but rows only have 4`},
+		{"row past the top", `{` + grid + `, "animations": {"a": {"row": 2}}}`,
+			`animation "a" uses frame 8, but there are only 8`},
+		{"unknown frame", `{` + grid + `, "animations": {"a": {"frames": [1, 9]}}}`,
+			`animation "a" uses frame 9, but there are only 8`},
+		{"negative frame", `{` + grid + `, "animations": {"a": {"frames": [-1]}}}`,
+			`animation "a" uses frame -1, but there are only 8`},
+		{"no frames in an animation", `{` + grid + `, "animations": {"a": {"frames": []}}}`,
+			`animation "a" has no frames`},
+		{"too few durations", `{` + grid + `, "animations": {"a": {"row": 1, "durations": [0.5, 0.5]}}}`,
+			`animation "a" has 4 frames but 2 durations`},
+		{"too many durations", `{` + grid + `, "animations": {"a": {"frames": [0], "durations": [0.5, 0.5]}}}`,
+			`animation "a" has 1 frames but 2 durations`},
+		{"frame lasting no time", `{` + grid + `, "animations": {"a": {"frames": [0, 1], "durations": [0.5, 0]}}}`,
+			`animation "a" has a frame that doesn't last any time`},
+	}
+	for _, test := range tests {
+		_, err := buildSidecar(t, test.data)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%s: got error %v, want %q", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package assets
+
+import (
+	gogui "GoGui"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+/*
+	Files is where every sprite, level and settings file is read from. A file in the working directory is used if
+	there is one, otherwise the one built into the binary, so the game runs from anywhere. Change where it looks first
+	with Override.
+*/
+var Files fs.FS = layers{os.DirFS("."), gogui.Defaults}
+
+/*
+	Reads files from dir before falling back to the ones built into the binary. An empty dir uses only the built in
+	files.
+*/
+func Override(dir string) {
+	if dir == "" {
+		Files = gogui.Defaults
+		return
+	}
+	Files = layers{os.DirFS(dir), gogui.Defaults}
+}
+
+/*
+	Opens a file from Files. A path outside of them, like an absolute one, is opened from disk as it is.
+*/
+func Open(path string) (fs.File, error) {
+	if name, ok := inside(path); ok {
+		return Files.Open(name)
+	}
+	return os.Open(path)
+}
+
+/*
+	Reads the whole of a file, looked for the same way as Open
+*/
+func ReadFile(path string) ([]byte, error) {
+	if name, ok := inside(path); ok {
+		return fs.ReadFile(Files, name)
+	}
+	return os.ReadFile(path)
+}
+
+/*
+	Describes a file, looked for the same way as Open. Files built into the binary never change, so they have no
+	modification time.
+*/
+func Stat(path string) (fs.FileInfo, error) {
+	if name, ok := inside(path); ok {
+		return fs.Stat(Files, name)
+	}
+	return os.Stat(path)
+}
+
+/*
+	The name of path within Files, and whether it's in there at all
+*/
+func inside(path string) (string, bool) {
+	if filepath.IsAbs(path) {
+		return "", false
+	}
+	name := filepath.ToSlash(filepath.Clean(path))
+	return name, fs.ValidPath(name)
+}
+
+/*
+	layers is a stack of file systems, a file is read from the first one that has it
+*/
+type layers []fs.FS
+
+func (l layers) Open(name string) (fs.File, error) {
+	var err error
+	for _, layer := range l {
+		var file fs.File
+		file, err = layer.Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return file, err
+		}
+	}
+	return nil, err
+}
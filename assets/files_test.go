@@ -0,0 +1,89 @@
+package assets
+
+import (
+	gogui "GoGui"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+	Puts Files back how it was once the test is over
+*/
+func keepFiles(t *testing.T) {
+	old := Files
+	t.Cleanup(func() { Files = old })
+}
+
+/*
+	Run from a directory with nothing in it, every file comes from the ones built into the binary
+*/
+func TestEmbeddedFallback(t *testing.T) {
+	keepFiles(t)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	Override(".") //the same as the -assets flag's default
+
+	data, err := ReadFile("entities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedded, err := fs.ReadFile(gogui.Defaults, "entities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, embedded) {
+		t.Error("entities.json isn't the one built into the binary")
+	}
+	if _, err := Stat("sprites/rings.png"); err != nil {
+		t.Errorf("couldn't find a built in spritesheet: %v", err)
+	}
+	if _, err := ReadFile("nothing.json"); !os.IsNotExist(err) {
+		t.Errorf("reading a file that's nowhere gave %v, want a not exist error", err)
+	}
+}
+
+/*
+	A file in the override directory is used instead of the built in one, and anything it doesn't have still comes
+	from the binary
+*/
+func TestOverrideShadowsEmbedded(t *testing.T) {
+	keepFiles(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "entities.json"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	Override(dir)
+
+	if data, err := ReadFile("entities.json"); err != nil || string(data) != "mine" {
+		t.Errorf("read %q, %v from the override, want mine", data, err)
+	}
+	if data, err := ReadFile("./entities.json"); err != nil || string(data) != "mine" {
+		t.Errorf("read %q, %v through ./ from the override, want mine", data, err)
+	}
+	embedded, err := fs.ReadFile(gogui.Defaults, "controls.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ReadFile("controls.txt"); err != nil || !bytes.Equal(data, embedded) {
+		t.Errorf("controls.txt isn't the built in one when the override doesn't have it: %v", err)
+	}
+
+	Override("") //only what's built in
+	if data, err := ReadFile("entities.json"); err != nil || string(data) == "mine" {
+		t.Errorf("read %q, %v with no override, want the built in entities.json", data, err)
+	}
+
+	//a path outside of Files is read from disk as it is
+	if data, err := ReadFile(filepath.Join(dir, "entities.json")); err != nil || string(data) != "mine" {
+		t.Errorf("read %q, %v from an absolute path, want mine", data, err)
+	}
+}
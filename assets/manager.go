@@ -0,0 +1,148 @@
+package assets
+
+import (
+	"github.com/faiface/pixel"
+	"image"
+	"image/color"
+	"log"
+	"strings"
+	"time"
+)
+
+const checkerSize = 8 //size of each square of the placeholder, in pixels
+
+/*
+	Manager loads every picture and atlas once and hands out the same one after that. Anything that can't be loaded
+	is swapped for a checkerboard so the game keeps running, and Reload picks up files that change on disk.
+*/
+type Manager struct {
+	pictures map[string]pixel.Picture
+	atlases  map[string]*Atlas
+	modTimes map[string]time.Time //when each loaded file was last changed, zero if it was missing
+}
+
+/*
+	Creates a manager with nothing loaded yet
+*/
+func NewManager() *Manager {
+	return &Manager{map[string]pixel.Picture{}, map[string]*Atlas{}, map[string]time.Time{}}
+}
+
+/*
+	The picture at path, loading it the first time it's asked for. If it can't be loaded a checkerboard is given
+	instead.
+*/
+func (m *Manager) Picture(path string) pixel.Picture {
+	if pic, ok := m.pictures[path]; ok {
+		return pic
+	}
+	m.watch(path)
+	pic, err := LoadPicture(path)
+	if err != nil {
+		log.Println("using a placeholder for " + path + ": " + err.Error())
+		pic = Placeholder()
+	}
+	m.pictures[path] = pic
+	return pic
+}
+
+/*
+	The atlas of the spritesheet at path, loading it the first time it's asked for. If it can't be loaded a single
+	checkerboard frame is given instead, which stands in for every frame the sheet should have had.
+*/
+func (m *Manager) Atlas(path string) *Atlas {
+	if atlas, ok := m.atlases[path]; ok {
+		return atlas
+	}
+	m.watch(path)
+	m.watch(sidecarPath(path))
+	atlas, err := LoadAtlas(path)
+	if err != nil {
+		log.Println("using a placeholder for " + path + ": " + err.Error())
+		atlas = placeholderAtlas()
+	}
+	m.atlases[path] = atlas
+	return atlas
+}
+
+/*
+	Loads every file that's changed since it was last loaded, including ones that were missing and have now turned
+	up. Atlases are updated in place, pictures have to be asked for again. Gives back the paths of the pictures and
+	atlases that changed.
+*/
+func (m *Manager) Reload() []string {
+	changed := map[string]bool{}
+	for path, modTime := range m.modTimes {
+		info, err := Stat(path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			continue
+		}
+		m.modTimes[path] = info.ModTime()
+		if strings.HasSuffix(path, ".json") { //a sidecar changing changes its sheet
+			path = strings.TrimSuffix(path, ".json") + ".png"
+		}
+		changed[path] = true
+	}
+
+	var reloaded []string
+	for path := range changed {
+		if _, ok := m.pictures[path]; ok {
+			if pic, err := LoadPicture(path); err != nil {
+				log.Println("couldn't reload " + path + ": " + err.Error())
+			} else {
+				m.pictures[path] = pic
+				reloaded = append(reloaded, path)
+			}
+		}
+		if atlas, ok := m.atlases[path]; ok {
+			if fresh, err := LoadAtlas(path); err != nil {
+				log.Println("couldn't reload " + path + ": " + err.Error())
+			} else {
+				*atlas = *fresh
+				reloaded = append(reloaded, path)
+			}
+		}
+	}
+	for _, path := range reloaded {
+		log.Println("reloaded " + path)
+	}
+	return reloaded
+}
+
+/*
+	Remembers when a file was last changed so Reload can tell if it changes again
+*/
+func (m *Manager) watch(path string) {
+	info, err := Stat(path)
+	if err != nil {
+		m.modTimes[path] = time.Time{} //missing for now, reload it if it turns up
+		return
+	}
+	m.modTimes[path] = info.ModTime()
+}
+
+/*
+	A magenta and black checkerboard, impossible to miss, for drawing in place of a picture that's missing
+*/
+func Placeholder() pixel.Picture {
+	img := image.NewRGBA(image.Rect(0, 0, checkerSize*8, checkerSize*8))
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if (x/checkerSize+y/checkerSize)%2 == 0 {
+				img.Set(x, y, color.RGBA{255, 0, 255, 255})
+			} else {
+				img.Set(x, y, color.RGBA{0, 0, 0, 255})
+			}
+		}
+	}
+	return pixel.PictureDataFromImage(img)
+}
+
+/*
+	An atlas with the checkerboard as its only frame
+*/
+func placeholderAtlas() *Atlas {
+	pic := Placeholder()
+	frame := Frame{pic.Bounds(), pic.Bounds().Size().Scaled(0.5)}
+	return &Atlas{pic, []Frame{frame}, []*pixel.Sprite{pixel.NewSprite(pic, frame.Rect)}, map[string]Animation{}}
+}
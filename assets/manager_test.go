@@ -0,0 +1,114 @@
+package assets
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+/*
+	A PNG of the given size, for putting in a test's files
+*/
+func testPNG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+/*
+	Reads files from files instead of the working directory for the rest of the test
+*/
+func useFiles(t *testing.T, files fs.FS) {
+	keepFiles(t)
+	Files = files
+}
+
+/*
+	The same picture or atlas is handed out every time it's asked for
+*/
+func TestManagerCaches(t *testing.T) {
+	useFiles(t, fstest.MapFS{
+		"bg.png":     {Data: testPNG(t, 40, 30)},
+		"sheet.png":  {Data: testPNG(t, 64, 16)},
+		"sheet.json": {Data: []byte(`{"grid": {"w": 16, "h": 16}, "animations": {"spin": {"row": 0}}}`)},
+	})
+	m := NewManager()
+	if m.Picture("bg.png") != m.Picture("bg.png") {
+		t.Error("got a different picture the second time")
+	}
+	atlas := m.Atlas("sheet.png")
+	if m.Atlas("sheet.png") != atlas {
+		t.Error("got a different atlas the second time")
+	}
+	if len(atlas.Frames) != 4 || len(atlas.Sprites) != 4 || len(atlas.Animations["spin"].Frames) != 4 {
+		t.Errorf("atlas has %d frames, %d sprites and a %d frame spin, want 4 of each", len(atlas.Frames),
+			len(atlas.Sprites), len(atlas.Animations["spin"].Frames))
+	}
+}
+
+/*
+	A picture or atlas that's missing is a checkerboard, and any frame of a missing atlas is its one frame
+*/
+func TestManagerPlaceholder(t *testing.T) {
+	useFiles(t, fstest.MapFS{})
+	m := NewManager()
+	if got, want := m.Picture("gone.png").Bounds(), Placeholder().Bounds(); got != want {
+		t.Errorf("missing picture is %v, want the placeholder's %v", got, want)
+	}
+	atlas := m.Atlas("gone.png")
+	if len(atlas.Frames) != 1 || atlas.Pic.Bounds() != Placeholder().Bounds() {
+		t.Fatalf("missing atlas has %d frames on a %v picture, want the placeholder's one", len(atlas.Frames),
+			atlas.Pic.Bounds())
+	}
+	if frame, sprite := atlas.Frame(7); frame != atlas.Frames[0] || sprite != atlas.Sprites[0] {
+		t.Error("frame 7 of the placeholder wasn't its only frame")
+	}
+}
+
+/*
+	Reload picks up sheets and sidecars that change, and ones that were missing and turn up, updating the atlas that
+	was already handed out
+*/
+func TestManagerReload(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	files := fstest.MapFS{
+		"sheet.png":  {Data: testPNG(t, 64, 16), ModTime: start},
+		"sheet.json": {Data: []byte(`{"grid": {"w": 16, "h": 16}}`), ModTime: start},
+	}
+	useFiles(t, files)
+	m := NewManager()
+	atlas := m.Atlas("sheet.png")
+	missing := m.Atlas("later.png")
+	if reloaded := m.Reload(); len(reloaded) != 0 {
+		t.Errorf("reloaded %v when nothing changed", reloaded)
+	}
+
+	files["sheet.json"] = &fstest.MapFile{Data: []byte(`{"grid": {"w": 32, "h": 16}}`), ModTime: start.Add(time.Second)}
+	if reloaded := m.Reload(); len(reloaded) != 1 || reloaded[0] != "sheet.png" {
+		t.Errorf("reloaded %v after the sidecar changed, want just sheet.png", reloaded)
+	}
+	if m.Atlas("sheet.png") != atlas || len(atlas.Frames) != 2 {
+		t.Errorf("atlas has %d frames after its sidecar changed, want the same atlas cut into 2", len(atlas.Frames))
+	}
+
+	files["sheet.png"] = &fstest.MapFile{Data: testPNG(t, 128, 16), ModTime: start.Add(2 * time.Second)}
+	m.Reload()
+	if len(atlas.Frames) != 4 || atlas.Pic.Bounds().W() != 128 {
+		t.Errorf("atlas has %d frames on a %v picture after the sheet changed, want 4 on a 128 wide one",
+			len(atlas.Frames), atlas.Pic.Bounds())
+	}
+
+	files["later.png"] = &fstest.MapFile{Data: testPNG(t, 10, 10), ModTime: start}
+	if reloaded := m.Reload(); len(reloaded) != 1 || reloaded[0] != "later.png" {
+		t.Errorf("reloaded %v after a missing sheet turned up, want just later.png", reloaded)
+	}
+	if m.Atlas("later.png") != missing || missing.Pic.Bounds().W() != 10 {
+		t.Errorf("the placeholder wasn't swapped for the sheet that turned up in place, it's %v", missing.Pic.Bounds())
+	}
+}
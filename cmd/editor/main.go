@@ -0,0 +1,378 @@
+package main
+
+import (
+	"GoGui/assets"
+	"GoGui/level"
+	"GoGui/palette"
+	"GoGui/world"
+	"fmt"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/pixelgl"
+	"golang.org/x/image/colornames"
+	"log"
+	"math"
+	"os"
+	"time"
+)
+
+var editorBarriers []level.Barrier
+var rings []pixel.Vec
+var goblins []pixel.Vec
+var teds []pixel.Vec
+var ringSheet *assets.Atlas
+var goblinSheet *assets.Atlas
+var tedSheet *assets.Atlas
+
+/*
+	Reads in the level being edited. If there isn't a level file yet, the old layout.txt and items.txt are imported
+	so they get saved in the new format.
+*/
+func eLoadLevel(path string) *level.Level {
+	lvl, err := level.Load(path)
+	if os.IsNotExist(err) { //nothing to import either, start from scratch
+		lvl, err = level.New(), nil
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, problem := range lvl.Problems {
+		log.Println(problem)
+	}
+	if len(lvl.Problems) > 0 { //the rows these are about were left out, so saving would delete them from the file
+		log.Fatalf("refusing to edit %s with %d problems, fix them by hand first so no rows are lost", path, len(lvl.Problems))
+	}
+
+	editorBarriers = append(editorBarriers, lvl.Barriers...)
+	for _, item := range lvl.Items {
+		eAddItem(item.Tag, item.Pos)
+	}
+	return lvl
+}
+
+/*
+	Shows an item that has been placed in the level
+*/
+func eAddItem(tag string, pos pixel.Vec) {
+	if tag == "ring" {
+		rings = append(rings, pos)
+	} else if tag == "ted" {
+		teds = append(teds, pos)
+	} else if tag == "goblin" {
+		goblins = append(goblins, pos)
+	}
+}
+
+/*
+	Shows what's being placed in the title bar, since there's nowhere else in the editor to say it
+*/
+func eSetTitle(win *pixelgl.Window, barrierMode bool, triggerMode bool, layer string) {
+	who := "everyone"
+	if layer != "" {
+		who = layer + "s only"
+	}
+	switch {
+	case barrierMode:
+		win.SetTitle("Editor: placing barriers that block " + who)
+	case triggerMode:
+		win.SetTitle("Editor: placing triggers set off by " + who)
+	default:
+		win.SetTitle("Editor: placing items")
+	}
+}
+
+/*
+	Draws an item of the same kind at every position, all in one batch
+*/
+func eDrawItems(win *pixelgl.Window, batch *pixel.Batch, sheet *assets.Atlas, positions []pixel.Vec) {
+	batch.Clear()
+	for _, pos := range positions {
+		sheet.Sprites[0].Draw(batch, pixel.IM.Moved(pos.Add(sheet.Frames[0].Offset())))
+	}
+	batch.Draw(win)
+}
+
+/*
+	Saves the level after every change so nothing is lost when the editor is closed
+*/
+func eSaveLevel(lvl *level.Level, path string) {
+	if err := lvl.Write(path); err != nil {
+		log.Fatal(err)
+	}
+}
+
+/*
+	Main function that creates the window and runs the program
+*/
+func runEditor() {
+	cfg := pixelgl.WindowConfig{ //set up window
+		Title:  "Game",
+		Bounds: pixel.R(0, 0, 1300, 1000),
+		VSync:  true, //refreshes at a consistent rate
+	}
+	win, err := pixelgl.NewWindow(cfg) //create window
+	if err != nil {
+		panic(err)
+	}
+
+	defs, err := world.ReadDefinitions("entities.json") //the game's own spritesheets, so items look the same in both
+	if err != nil {
+		log.Fatal(err)
+	}
+	manager := assets.NewManager() //stands in placeholders for any pictures that are missing
+	ringSheet = eLoadSheet(defs, manager, "ring")
+	goblinSheet = eLoadSheet(defs, manager, "goblin")
+	tedSheet = eLoadSheet(defs, manager, "ted")
+
+	lvl := eLoadLevel(level.DefaultFile)
+
+	var background *pixel.Sprite //nil if the level doesn't have a background image
+	if lvl.BackgroundImage != "" {
+		bgimg := manager.Picture(lvl.BackgroundImage) //get background image
+		background = pixel.NewSprite(bgimg, bgimg.Bounds())
+	}
+
+	var (
+		camPos       = pixel.ZV
+		camSpeed     = 500.0
+		camZoom      = 1.0
+		camZoomSpeed = 1.2
+
+		pointA          = pixel.ZV
+		pointB          = pixel.ZV
+		placeBarrier    = true
+		activePlacement = false
+		barrierMode     = false
+		triggerMode     = false
+		layer           = "" //layer new barriers go on, and the one new triggers are set off by. "" for walls and everyone
+		ringMode        = true
+		goblinMode      = false
+		tedMode         = false
+		placeHolder     *pixel.Sprite //follows mouse in item placement mode
+
+		ringBatch   = pixel.NewBatch(&pixel.TrianglesData{}, ringSheet.Pic) //each kind of item is drawn in one go
+		goblinBatch = pixel.NewBatch(&pixel.TrianglesData{}, goblinSheet.Pic)
+		tedBatch    = pixel.NewBatch(&pixel.TrianglesData{}, tedSheet.Pic)
+		imd         = imdraw.New(nil)
+		second      = time.Tick(time.Second) //how often pictures are checked for changes
+	)
+	eSetTitle(win, barrierMode, triggerMode, layer)
+
+	last := time.Now()
+	for !win.Closed() {
+		//delta time
+		dt := time.Since(last).Seconds()
+		last = time.Now()
+		//camera
+		cam := pixel.IM.Scaled(camPos, camZoom).Moved(win.Bounds().Center().Sub(camPos))
+		win.SetMatrix(cam)
+
+		placeHolder = ringSheet.Sprites[0] //initialize variable
+
+		//control camera
+		if win.Pressed(pixelgl.KeyLeft) || win.Pressed(pixelgl.KeyA) {
+			camPos.X -= camSpeed * dt
+		}
+		if win.Pressed(pixelgl.KeyRight) || win.Pressed(pixelgl.KeyD) {
+			camPos.X += camSpeed * dt
+		}
+		if win.Pressed(pixelgl.KeyDown) || win.Pressed(pixelgl.KeyS) {
+			camPos.Y -= camSpeed * dt
+		}
+		if win.Pressed(pixelgl.KeyUp) || win.Pressed(pixelgl.KeyW) {
+			camPos.Y += camSpeed * dt
+		}
+		camZoom *= math.Pow(camZoomSpeed, win.MouseScroll().Y)
+
+		if win.JustPressed(pixelgl.KeyTab) { //cycle between item, barrier and trigger
+			if barrierMode {
+				barrierMode, triggerMode = false, true
+			} else if triggerMode {
+				triggerMode = false
+			} else {
+				barrierMode = true
+			}
+			pointA = pixel.ZV //don't carry a half placed line or trigger over
+			activePlacement = false
+			placeBarrier = true
+			eSetTitle(win, barrierMode, triggerMode, layer)
+		}
+		if barrierMode || triggerMode { //pick who the next barrier blocks, or who sets off the next trigger
+			if win.JustPressed(pixelgl.Key1) {
+				layer = ""
+				eSetTitle(win, barrierMode, triggerMode, layer)
+			}
+			if win.JustPressed(pixelgl.Key2) {
+				layer = "player"
+				eSetTitle(win, barrierMode, triggerMode, layer)
+			}
+			if win.JustPressed(pixelgl.Key3) {
+				layer = "goblin"
+				eSetTitle(win, barrierMode, triggerMode, layer)
+			}
+		}
+
+		if barrierMode {
+
+			if win.JustPressed(pixelgl.MouseButtonLeft) && placeBarrier {
+				pointA = cam.Unproject(win.MousePosition())
+				activePlacement = true
+			}
+			if win.JustPressed(pixelgl.MouseButtonLeft) && activePlacement {
+				pointB = cam.Unproject(win.MousePosition())
+				bar := level.Barrier{A: pointA, B: pointB, Layer: layer}
+				editorBarriers = append(editorBarriers, bar)
+				if !(pointA.X == pointB.X && pointA.Y == pointB.Y) { //no stray dots
+					lvl.Barriers = append(lvl.Barriers, bar)
+					eSaveLevel(lvl, level.DefaultFile)
+				}
+				pointA = pointB
+				activePlacement = true
+				placeBarrier = false
+			}
+			if win.JustPressed(pixelgl.MouseButtonRight) { //right click to cancel current line
+				pointA = pixel.ZV
+				activePlacement = false
+				placeBarrier = true
+			}
+		} else if triggerMode { //drag out a rectangle with two clicks, one on each corner
+			if win.JustPressed(pixelgl.MouseButtonLeft) {
+				if !activePlacement {
+					pointA = cam.Unproject(win.MousePosition())
+					activePlacement = true
+				} else {
+					pointB = cam.Unproject(win.MousePosition())
+					if pointA.X != pointB.X && pointA.Y != pointB.Y { //no triggers without an area
+						area := pixel.R(pointA.X, pointA.Y, pointB.X, pointB.Y).Norm()
+						trigger := level.Trigger{Name: fmt.Sprintf("trigger%d", len(lvl.Triggers)+1), Min: area.Min, Max: area.Max}
+						if layer != "" {
+							trigger.Mask = []string{layer}
+						}
+						lvl.Triggers = append(lvl.Triggers, trigger)
+						eSaveLevel(lvl, level.DefaultFile)
+					}
+					pointA = pixel.ZV
+					activePlacement = false
+				}
+			}
+			if win.JustPressed(pixelgl.MouseButtonRight) { //right click to cancel the current trigger
+				pointA = pixel.ZV
+				activePlacement = false
+			}
+		} else { //item placement mode
+			if win.JustPressed(pixelgl.KeyR) { //toggle different items
+				ringMode = true
+				goblinMode = false
+				tedMode = false
+			}
+			if win.JustPressed(pixelgl.KeyG) {
+				goblinMode = true
+				ringMode = false
+				tedMode = false
+			}
+			if win.JustPressed(pixelgl.KeyT) {
+				tedMode = true
+				goblinMode = false
+				ringMode = false
+			}
+			if ringMode {
+				placeHolder = ringSheet.Sprites[0]
+				if win.JustPressed(pixelgl.MouseButtonLeft) {
+					pos := cam.Unproject(win.MousePosition())
+					eAddItem("ring", pos)
+					lvl.Items = append(lvl.Items, level.Item{Tag: "ring", Pos: pos})
+					eSaveLevel(lvl, level.DefaultFile)
+				}
+			} else if tedMode {
+				placeHolder = tedSheet.Sprites[0]
+				if win.JustPressed(pixelgl.MouseButtonLeft) {
+					pos := cam.Unproject(win.MousePosition())
+					eAddItem("ted", pos)
+					lvl.Items = append(lvl.Items, level.Item{Tag: "ted", Pos: pos})
+					eSaveLevel(lvl, level.DefaultFile)
+				}
+			} else if goblinMode {
+				placeHolder = goblinSheet.Sprites[0]
+				if win.JustPressed(pixelgl.MouseButtonLeft) {
+					pos := cam.Unproject(win.MousePosition())
+					eAddItem("goblin", pos)
+					lvl.Items = append(lvl.Items, level.Item{Tag: "goblin", Pos: pos})
+					eSaveLevel(lvl, level.DefaultFile)
+				}
+			}
+		}
+
+		win.Clear(colornames.Black) //refresh window, set color
+		if background != nil {
+			background.Draw(win, pixel.IM.Moved(lvl.Background))
+		}
+
+		eDrawItems(win, ringBatch, ringSheet, rings)
+		eDrawItems(win, goblinBatch, goblinSheet, goblins)
+		eDrawItems(win, tedBatch, tedSheet, teds)
+
+		if !barrierMode && !triggerMode {
+			placeHolder.Draw(win, pixel.IM.Moved(cam.Unproject(win.MousePosition())))
+		} else {
+			placeHolder.Draw(win, pixel.IM)
+		}
+
+		imd.Clear()
+
+		for _, line := range editorBarriers {
+			imd.Color = palette.LayerColor(line.Layer)
+			imd.Push(line.A)
+			imd.Push(line.B)
+			imd.Line(2)
+		}
+		for _, trigger := range lvl.Triggers {
+			imd.Color = colornames.Yellow
+			imd.Push(trigger.Min, trigger.Max)
+			imd.Rectangle(1)
+		}
+
+		if pointA != pixel.ZV && barrierMode { //ghost graphic that shows where the line will be placed
+			imd.Color = colornames.White
+			imd.Push(pointA, cam.Unproject(win.MousePosition()))
+			imd.Line(2)
+		}
+		if activePlacement && triggerMode { //and where the trigger will be
+			imd.Color = colornames.White
+			imd.Push(pointA, cam.Unproject(win.MousePosition()))
+			imd.Rectangle(1)
+		}
+
+		imd.Draw(win)
+
+		win.Update() //update window
+
+		select {
+		case <-second:
+			if len(manager.Reload()) > 0 { //pictures were changed on disk, draw with the new ones
+				ringBatch = pixel.NewBatch(&pixel.TrianglesData{}, ringSheet.Pic)
+				goblinBatch = pixel.NewBatch(&pixel.TrianglesData{}, goblinSheet.Pic)
+				tedBatch = pixel.NewBatch(&pixel.TrianglesData{}, tedSheet.Pic)
+				if background != nil {
+					bgimg := manager.Picture(lvl.BackgroundImage)
+					background = pixel.NewSprite(bgimg, bgimg.Bounds())
+				}
+			}
+		default:
+		}
+	}
+}
+
+/*
+	Loads the spritesheet items of the given kind are drawn from
+*/
+func eLoadSheet(defs *world.Definitions, manager *assets.Manager, tag string) *assets.Atlas {
+	e, err := defs.Spawn(tag, pixel.ZV) //a spawned entity knows which sheet its animator starts on
+	if err != nil || e.Sprite == nil {
+		log.Fatalf("entities.json has no sprite for %q", tag)
+	}
+	return manager.Atlas(defs.Sheets[e.Sprite.Sheet].Path)
+}
+
+func main() {
+	pixelgl.Run(runEditor)
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"GoGui/assets"
+	"GoGui/input"
+	"GoGui/level"
+	"GoGui/palette"
+	"GoGui/world"
+	"flag"
+	"fmt"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/colornames"
+	"golang.org/x/image/font/basicfont"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const transitionTime = 3.0 //seconds the screen between two levels is shown for
+
+/*
+	Basically what would normally be our main, reworked for pixel. Called in the main function.
+	Creates a window and all the things within it.
+*/
+func run() {
+	cfg := pixelgl.WindowConfig{ //set up window
+		Title:  "Game",
+		Bounds: pixel.R(0, 0, float64(*width), float64(*height)),
+		VSync:  *vsync, //refreshes at a consistent rate
+	}
+	if *fullscreen {
+		cfg.Monitor = pixelgl.PrimaryMonitor()
+		monitorW, monitorH := cfg.Monitor.Size()
+		cfg.Bounds = pixel.R(0, 0, monitorW, monitorH)
+	}
+	win, err := pixelgl.NewWindow(cfg) //create window
+	if err != nil {
+		panic(err)
+	}
+
+	//region Load our images
+	defs, err := world.ReadDefinitions(*entitiesPath) //what every kind of entity looks like and how it behaves
+	if err != nil {
+		log.Fatal(err)
+	}
+	manager := assets.NewManager()       //every picture is loaded through here, so it's cached and can be hot reloaded
+	sheets := map[string]*assets.Atlas{} //spritesheets by name, entities ask for them through their Sprite
+	for name, def := range defs.Sheets {
+		sheets[name] = manager.Atlas(def.Path)
+	}
+	batches := makeBatches(sheets)
+	//endregion
+
+	keys, err := input.ReadBindings(*controlsPath) //load key bindings
+	if os.IsNotExist(err) {
+		keys = input.DefaultBindings()
+	} else if err != nil {
+		log.Fatal(err)
+	}
+	devices := input.Multi{input.NewKeyboard(win, keys), input.NewGamepad(win, keys)}
+	var in world.Input = devices //where the controls for each step come from
+	var script *world.Script     //a replay being played back instead of reading the keyboard
+
+	played := *levelsPath //what a replay says the run was played on
+	if *levelPath != "" {
+		played = *levelPath
+	}
+	if *replayPath != "" {
+		replay, err := world.ReadReplay(*replayPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *seed == 0 { //play it with the seed it was recorded with, unless told otherwise
+			*seed = replay.Seed
+		}
+		if err := replay.Check(*seed, played); err != nil {
+			log.Fatal(*replayPath + ": " + err.Error())
+		}
+		script = &world.Script{Steps: replay.Steps}
+		in = script
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	log.Printf("playing with -seed %d", *seed) //so a run can be played again exactly
+
+	var recorder *world.Recorder //saves every step's controls so the run can be replayed
+	if *recordPath != "" {
+		recorder, err = world.NewRecorder(*recordPath, *seed, played)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				log.Println("failed to save replay: " + err.Error())
+			}
+		}()
+	}
+
+	levelPaths := []string{*levelPath} //every level, in the order they're played
+	if *levelPath == "" {
+		levelPaths, err = readManifest(*levelsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	levels, err := loadLevels(levelPaths, defs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	current := 0 //index of the level being played
+	w := startLevel(levels[current], defs, *seed)
+	scene := loadScenery(levels[current], manager)
+
+	var (
+		ringicon, _ = defs.Spawn("ring", pixel.ZV) //spinning ring next to the score, nil if there are no rings
+
+		transition = 0.0   //seconds left on the screen between two levels, 0 while playing
+		finished   = false //whether the last level has been completed
+		txtAtlas   = text.NewAtlas(basicfont.Face7x13, text.ASCII)
+		scoreText  = text.New(win.Bounds().Center().Sub(pixel.V(180, 150)), txtAtlas)
+		imd        = imdraw.New(nil) //debug graphics, cleared and reused every frame
+
+		camZoom = *zoom
+		frames  = 0
+		second  = time.Tick(time.Second)
+
+		DEBUG = *debug
+
+		accumulator = 0.0 //game time that has passed but hasn't been stepped through yet
+	)
+	logTriggers := func(t *world.Trigger, e *world.Entity, event world.TriggerEvent) {
+		if DEBUG && event != world.TriggerStay { //stay comes every step, far too often to log
+			log.Printf("%s %d %ss %s", e.Tag, e.ID, event, t.Name)
+		}
+	}
+	w.OnTrigger = logTriggers
+
+	last := time.Now() //main game loop
+	for !win.Closed() {
+		dt := time.Since(last).Seconds() //delta time
+		last = time.Now()
+		if dt > 0.25 { //after a big hitch, don't try to catch up all at once
+			dt = 0.25
+		}
+		accumulator += dt
+
+		in.Update()
+		for accumulator >= world.Tick { //step the world at a fixed rate, no matter the framerate
+			if transition > 0 { //nobody moves between levels, so replays don't record anything here either
+				transition -= world.Tick
+				accumulator -= world.Tick
+				if transition <= 0 {
+					transition = 0
+					score := w.Score
+					current++
+					w = startLevel(levels[current], defs, *seed+int64(current))
+					w.OnTrigger = logTriggers
+					scene = loadScenery(levels[current], manager)
+					w.Score = score //the score carries over from level to level
+				}
+				continue
+			}
+			if script != nil && script.Done() {
+				log.Println("replay finished, keyboard and gamepad are back in control")
+				in = devices
+				script = nil
+			}
+			controls := in.Controls()
+			if recorder != nil {
+				if err := recorder.Record(controls); err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			if controls.ToggleDebug {
+				DEBUG = !DEBUG //toggle debug mode
+			}
+			w.Step(world.Tick, controls)
+			accumulator -= world.Tick
+
+			if !finished && w.Complete() {
+				if current+1 < len(levels) {
+					transition = transitionTime
+				} else {
+					finished = true //stay in the last level, there's nowhere left to go
+				}
+			}
+		}
+		alpha := accumulator / world.Tick //how far we are between the last step and the next one
+
+		playerTruePos := pixel.Lerp(w.Player.PrevPos, w.Player.Pos, alpha) //draw positions are blended between steps
+		playerPos := win.Bounds().Center().Sub(playerTruePos)              //how far the player is from the middle of the window
+
+		//camera
+		cam := pixel.IM.Scaled(win.Bounds().Center().Sub(playerPos), camZoom).Moved(playerPos)
+		win.SetMatrix(cam)
+
+		win.Clear(colornames.Black) //refresh window, set color
+		if transition > 0 {
+			drawMessage(win, txtAtlas, win.Bounds().Center(), "Level complete!\n\nNext up: "+levels[current+1].Name)
+			win.Update()
+			continue
+		}
+		if scene.background != nil {
+			scene.background.Draw(win, pixel.IM.Moved(levels[current].Background))
+		}
+
+		//draw everything in the order the world sorted it. Entities in a row that share a sheet are batched together,
+		//and the batch is only drawn once the sheet changes so nothing ends up in front of what it should be behind
+		var batch *pixel.Batch
+		for _, e := range w.Entities {
+			if e.Sprite == nil {
+				continue
+			}
+			if next := batches[e.Sprite.Sheet]; next != batch {
+				if batch != nil {
+					batch.Draw(win)
+				}
+				batch = next
+				batch.Clear()
+			}
+			frame, sprite := sheets[e.Sprite.Sheet].Frame(frameOf(e))
+			sprite.Draw(batch, //the frame's pivot goes on the entity's position
+				pixel.IM.Moved(frame.Offset()).ScaledXY(pixel.ZV, e.Scale).Moved(pixel.Lerp(e.PrevPos, e.Pos, alpha)))
+		}
+		if batch != nil {
+			batch.Draw(win)
+		}
+
+		if scene.overlay != nil {
+			scene.overlay.Draw(win, pixel.IM.Moved(levels[current].Background))
+		}
+
+		//draw barriers (DEBUG)
+		if DEBUG {
+			imd.Clear()
+			for _, e := range w.Entities {
+				if e.Collider == nil {
+					continue
+				}
+				imd.Color = colornames.Cyan
+				if e == w.Player {
+					imd.Color = colornames.Blue
+				}
+				imd.Push(e.Collider.Center)
+				imd.Circle(e.Collider.Radius, 2)
+			}
+			for _, line := range w.Barriers {
+				imd.Color = palette.LayerColor(line.Layer)
+				imd.Push(line.A) //draw a line with 2 points
+				imd.Push(line.B)
+				imd.Line(2)
+			}
+			for _, t := range w.Triggers {
+				imd.Color = colornames.Yellow
+				imd.Push(t.Rect.Min, t.Rect.Max)
+				imd.Rectangle(1)
+			}
+
+			imd.Draw(win) //draw debug graphics
+		}
+
+		//draw score stuff at top of screen
+		scoreText.Clear()
+		fmt.Fprintln(scoreText, w.Score)
+		scoreText.Draw(win, pixel.IM.Scaled(win.Bounds().Center(), camZoom).Moved(playerTruePos))
+		if ringicon != nil && ringicon.Sprite != nil {
+			if ringicon.Animator != nil {
+				ringicon.Animator.Update(ringicon, dt)
+			}
+			_, sprite := sheets[ringicon.Sprite.Sheet].Frame(frameOf(ringicon))
+			sprite.Draw(win,
+				pixel.IM.Scaled(win.Bounds().Center(), camZoom/3).Moved(playerTruePos.Add(pixel.V(50, 39))))
+		}
+
+		if finished {
+			drawMessage(win, txtAtlas, pixel.V(win.Bounds().Center().X, win.Bounds().Max.Y-100), "All levels complete!")
+		}
+
+		win.Update() //update window
+
+		frames++ //keep track of framerate and display it on window title
+		select {
+		case <-second:
+			win.SetTitle(fmt.Sprintf("%s | FPS: %d", cfg.Title, frames))
+			frames = 0
+			if len(manager.Reload()) > 0 { //pictures were changed on disk, draw with the new ones
+				batches = makeBatches(sheets)
+				scene = loadScenery(levels[current], manager)
+			}
+		default:
+		}
+
+	}
+}
+
+/*
+	Reads in the list of levels to play. Without a manifest, level.json is played on its own.
+*/
+func readManifest(manifestPath string) ([]string, error) {
+	manifest, err := level.ReadManifest(manifestPath)
+	if os.IsNotExist(err) {
+		return []string{level.DefaultFile}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifest.Levels, nil
+}
+
+/*
+	Reads in every level up front, so problems in any of them show up before the game starts
+*/
+func loadLevels(paths []string, defs *world.Definitions) ([]*level.Level, error) {
+	var levels []*level.Level
+	for _, path := range paths {
+		lvl, err := level.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		lvl.CheckTags(func(tag string) bool {
+			_, ok := defs.Archetypes[tag]
+			return ok
+		})
+		for _, problem := range lvl.Problems { //the rows these are about were left out of the level
+			log.Println(problem)
+		}
+		levels = append(levels, lvl)
+	}
+	if *strict {
+		if err := level.Strict(levels...); err != nil {
+			return nil, err
+		}
+	}
+	return levels, nil
+}
+
+/*
+	The images drawn behind and in front of a level, nil if it doesn't have one
+*/
+type scenery struct {
+	background *pixel.Sprite
+	overlay    *pixel.Sprite
+}
+
+/*
+	Creates a fresh world for a level and seeds its random numbers
+*/
+func startLevel(lvl *level.Level, defs *world.Definitions, seed int64) *world.World {
+	w := world.New(lvl.Spawn, defs) //the world the game takes place in
+	w.Seed(seed)
+	w.LoadLevel(lvl)
+	return w
+}
+
+/*
+	Gets the images that go with a level
+*/
+func loadScenery(lvl *level.Level, manager *assets.Manager) scenery {
+	var scene scenery
+	if lvl.BackgroundImage != "" {
+		pic := manager.Picture(lvl.BackgroundImage)
+		scene.background = pixel.NewSprite(pic, pic.Bounds())
+	}
+	if lvl.OverlayImage != "" {
+		pic := manager.Picture(lvl.OverlayImage)
+		scene.overlay = pixel.NewSprite(pic, pic.Bounds())
+	}
+	return scene
+}
+
+/*
+	Makes a batch for every sheet, so everything on a sheet can be drawn in one go
+*/
+func makeBatches(sheets map[string]*assets.Atlas) map[string]*pixel.Batch {
+	batches := map[string]*pixel.Batch{}
+	for name, sheet := range sheets {
+		batches[name] = pixel.NewBatch(&pixel.TrianglesData{}, sheet.Pic)
+	}
+	return batches
+}
+
+/*
+	Writes a message centered on a point of the window, whatever the camera is looking at
+*/
+func drawMessage(win *pixelgl.Window, atlas *text.Atlas, at pixel.Vec, msg string) {
+	txt := text.New(at, atlas)
+	for _, line := range strings.Split(msg, "\n") {
+		txt.Dot.X -= txt.BoundsOf(line).W() / 2 //center every line
+		fmt.Fprintln(txt, line)
+	}
+	win.SetMatrix(pixel.IM)
+	txt.Draw(win, pixel.IM.Scaled(at, 2))
+}
+
+/*
+	The frame of its spritesheet an entity is showing
+*/
+func frameOf(e *world.Entity) int {
+	if e.Animator == nil {
+		return 0
+	}
+	return e.Animator.Frame
+}
+
+var assetsDir = flag.String("assets", ".", "read sprites, levels and settings from `dir` first, using the ones built into the game for anything it doesn't have")
+var recordPath = flag.String("record", "", "record the controls of this run to a replay `file`")
+var entitiesPath = flag.String("entities", "entities.json", "read entity definitions from `file`")
+var controlsPath = flag.String("controls", "controls.txt", "read key bindings from `file`")
+var replayPath = flag.String("replay", "", "play back the controls from a replay `file` instead of the keyboard")
+var levelsPath = flag.String("levels", "levels.json", "read the list of levels to play from `file`")
+var levelPath = flag.String("level", "", "play only the level in `file`, instead of every level in -levels")
+var width = flag.Int("width", 1300, "width of the window in pixels")
+var height = flag.Int("height", 1000, "height of the window in pixels")
+var fullscreen = flag.Bool("fullscreen", false, "fill the primary monitor instead of opening a window")
+var vsync = flag.Bool("vsync", true, "wait for the monitor to refresh before drawing each frame")
+var debug = flag.Bool("debug", false, "start in debug mode, with colliders and barriers drawn")
+var zoom = flag.Float64("zoom", 2.0, "how far the camera is zoomed in")
+var seed = flag.Int64("seed", 0, "seed for the world's random numbers, 0 picks one from the clock")
+var strict = flag.Bool("strict", false, "refuse to start if anything in the level is malformed, instead of leaving it out")
+
+func main() {
+	flag.Parse()
+	assets.Override(*assetsDir)
+	pixelgl.Run(run)
+}
@@ -0,0 +1,13 @@
+/*
+	Package gogui holds the sprites, levels and settings the game ships with, built into the binary so it runs from
+	any directory.
+*/
+package gogui
+
+import "embed"
+
+/*
+	Defaults is every file the game needs to run, laid out the same as at the top of the repository
+*/
+//go:embed sprites/*.png sprites/*.json entities.json controls.txt levels.json level.json layout.txt items.txt
+var Defaults embed.FS
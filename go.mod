@@ -2,7 +2,10 @@ module GoGui
 
 go 1.18
 
-require github.com/faiface/pixel v0.10.0
+require (
+	github.com/faiface/pixel v0.10.0
+	golang.org/x/image v0.0.0-20220302094943-723b81ca9867
+)
 
 require (
 	github.com/faiface/glhf v0.0.0-20181018222622-82a6317ac380 // indirect
@@ -11,5 +14,4 @@ require (
 	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20191125211704-12ad95a8df72 // indirect
 	github.com/go-gl/mathgl v0.0.0-20190416160123-c4601bc793c7 // indirect
 	github.com/pkg/errors v0.8.1 // indirect
-	golang.org/x/image v0.0.0-20220302094943-723b81ca9867 // indirect
 )
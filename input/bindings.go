@@ -0,0 +1,118 @@
+/*
+	Package input turns real devices into the abstract world.Controls the game runs on.
+*/
+package input
+
+import (
+	"GoGui/assets"
+	"bufio"
+	"fmt"
+	"github.com/faiface/pixel/pixelgl"
+	"strings"
+)
+
+// the actions a key can be bound to
+const (
+	Left        = "left"
+	Right       = "right"
+	Up          = "up"
+	Down        = "down"
+	Respawn     = "respawn"
+	ToggleDebug = "debug"
+)
+
+/*
+	Binding is every key and gamepad button that triggers an action. Any one of them is enough.
+*/
+type Binding struct {
+	Keys    []pixelgl.Button
+	Buttons []pixelgl.GamepadButton
+}
+
+/*
+	Bindings holds the Binding for each action
+*/
+type Bindings map[string]Binding
+
+// gamepad buttons are written with a Pad prefix in bindings files so they can't be mixed up with keys
+var padNames = map[string]pixelgl.GamepadButton{
+	"PadA":           pixelgl.ButtonA,
+	"PadB":           pixelgl.ButtonB,
+	"PadX":           pixelgl.ButtonX,
+	"PadY":           pixelgl.ButtonY,
+	"PadLeftBumper":  pixelgl.ButtonLeftBumper,
+	"PadRightBumper": pixelgl.ButtonRightBumper,
+	"PadBack":        pixelgl.ButtonBack,
+	"PadStart":       pixelgl.ButtonStart,
+	"PadGuide":       pixelgl.ButtonGuide,
+	"PadLeftThumb":   pixelgl.ButtonLeftThumb,
+	"PadRightThumb":  pixelgl.ButtonRightThumb,
+	"PadDpadUp":      pixelgl.ButtonDpadUp,
+	"PadDpadRight":   pixelgl.ButtonDpadRight,
+	"PadDpadDown":    pixelgl.ButtonDpadDown,
+	"PadDpadLeft":    pixelgl.ButtonDpadLeft,
+}
+
+/*
+	The controls the game has always shipped with
+*/
+func DefaultBindings() Bindings {
+	return Bindings{
+		Left:        {[]pixelgl.Button{pixelgl.KeyLeft, pixelgl.KeyA}, []pixelgl.GamepadButton{pixelgl.ButtonDpadLeft}},
+		Right:       {[]pixelgl.Button{pixelgl.KeyRight, pixelgl.KeyD}, []pixelgl.GamepadButton{pixelgl.ButtonDpadRight}},
+		Up:          {[]pixelgl.Button{pixelgl.KeyUp, pixelgl.KeyW}, []pixelgl.GamepadButton{pixelgl.ButtonDpadUp}},
+		Down:        {[]pixelgl.Button{pixelgl.KeyDown, pixelgl.KeyS}, []pixelgl.GamepadButton{pixelgl.ButtonDpadDown}},
+		Respawn:     {[]pixelgl.Button{pixelgl.KeyR}, []pixelgl.GamepadButton{pixelgl.ButtonY}},
+		ToggleDebug: {[]pixelgl.Button{pixelgl.KeyTab}, []pixelgl.GamepadButton{pixelgl.ButtonBack}},
+	}
+}
+
+/*
+	Reads key bindings from a file. Each line is an action followed by the names of its keys and gamepad buttons,
+	e.g. "left,Left,A,PadDpadLeft,". Actions missing from the file keep their default keys.
+*/
+func ReadBindings(path string) (Bindings, error) {
+	file, err := assets.Open(path) //open to read
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keyNames := map[string]pixelgl.Button{} //look up keys by the same names pixelgl prints
+	for b := pixelgl.KeyUnknown; b <= pixelgl.KeyLast; b++ {
+		keyNames[b.String()] = b
+	}
+
+	bindings := DefaultBindings()
+	lineNum := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNum++
+		lineElems := strings.Split(strings.TrimSuffix(scanner.Text(), ","), ",") //split on commas
+		if len(lineElems) < 2 {
+			continue //blank lines and actions with no keys
+		}
+		action := lineElems[0]
+		if _, ok := bindings[action]; !ok {
+			return nil, fmt.Errorf("%s:%d: unknown action %q", path, lineNum, action)
+		}
+		var binding Binding
+		for _, name := range lineElems[1:] {
+			if button, ok := padNames[name]; ok {
+				binding.Buttons = append(binding.Buttons, button)
+				continue
+			}
+			key, ok := keyNames[name]
+			if !ok || name == "Invalid" {
+				return nil, fmt.Errorf("%s:%d: unknown key %q", path, lineNum, name)
+			}
+			binding.Keys = append(binding.Keys, key)
+		}
+		bindings[action] = binding
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
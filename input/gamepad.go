@@ -0,0 +1,102 @@
+package input
+
+import (
+	"GoGui/world"
+	"github.com/faiface/pixel/pixelgl"
+	"math"
+)
+
+/*
+	Gamepad reads the player's controls from the first connected gamepad or joystick. The left stick and the d-pad
+	both move the player.
+*/
+type Gamepad struct {
+	win  *pixelgl.Window
+	keys Bindings
+	js   pixelgl.Joystick //the joystick we're reading, -1 if none are connected
+
+	DeadZone float64 //how far the stick has to be pushed before the player moves, from 0 up to (not including) 1
+
+	respawn     bool //presses are held on to until the world steps, so they can't be missed or doubled
+	toggleDebug bool
+}
+
+func NewGamepad(win *pixelgl.Window, keys Bindings) *Gamepad {
+	return &Gamepad{win: win, keys: keys, js: -1, DeadZone: 0.25}
+}
+
+func (g *Gamepad) Update() {
+	g.js = -1
+	for js := pixelgl.Joystick1; js <= pixelgl.JoystickLast; js++ { //use whichever gamepad is plugged in first
+		if g.win.JoystickPresent(js) {
+			g.js = js
+			break
+		}
+	}
+	if g.js < 0 {
+		return
+	}
+	g.respawn = g.respawn || g.justPressed(Respawn)
+	g.toggleDebug = g.toggleDebug || g.justPressed(ToggleDebug)
+}
+
+func (g *Gamepad) Controls() world.Controls {
+	c := world.Controls{Respawn: g.respawn, ToggleDebug: g.toggleDebug}
+	g.respawn = false
+	g.toggleDebug = false
+	if g.js < 0 {
+		return c
+	}
+
+	//glfw's Y axis points down, ours points up
+	c.MoveX, c.MoveY = deadZone(g.win.JoystickAxis(g.js, pixelgl.AxisLeftX), -g.win.JoystickAxis(g.js, pixelgl.AxisLeftY), g.DeadZone)
+
+	if g.pressed(Left) { //the d-pad wins over the stick
+		c.MoveX = -1
+	} else if g.pressed(Right) {
+		c.MoveX = 1
+	}
+	if g.pressed(Down) {
+		c.MoveY = -1
+	} else if g.pressed(Up) {
+		c.MoveY = 1
+	}
+	return c
+}
+
+/*
+	Treats anything inside the dead zone as the stick being let go. Outside of it, the stick's distance from the
+	edge of the dead zone is stretched back out to 0 to 1 so the player can still move slowly.
+*/
+func deadZone(x float64, y float64, size float64) (float64, float64) {
+	length := math.Hypot(x, y)
+	if length < size {
+		return 0, 0
+	}
+	scale := math.Min((length-size)/(1-size), 1) / length
+	return x * scale, y * scale
+}
+
+/*
+	True if any gamepad button bound to the action is held down
+*/
+func (g *Gamepad) pressed(action string) bool {
+	for _, button := range g.keys[action].Buttons {
+		if g.win.JoystickPressed(g.js, button) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	True if any gamepad button bound to the action was pressed this frame
+*/
+func (g *Gamepad) justPressed(action string) bool {
+	for _, button := range g.keys[action].Buttons {
+		if g.win.JoystickJustPressed(g.js, button) {
+			return true
+		}
+	}
+	return false
+}
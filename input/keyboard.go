@@ -0,0 +1,68 @@
+package input
+
+import (
+	"GoGui/world"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+/*
+	Keyboard reads the player's controls from the window using a set of key bindings
+*/
+type Keyboard struct {
+	win  *pixelgl.Window
+	keys Bindings
+
+	respawn     bool //presses are held on to until the world steps, so they can't be missed or doubled
+	toggleDebug bool
+}
+
+func NewKeyboard(win *pixelgl.Window, keys Bindings) *Keyboard {
+	return &Keyboard{win: win, keys: keys}
+}
+
+func (k *Keyboard) Update() {
+	k.respawn = k.respawn || k.justPressed(Respawn)
+	k.toggleDebug = k.toggleDebug || k.justPressed(ToggleDebug)
+}
+
+func (k *Keyboard) Controls() world.Controls {
+	c := world.Controls{Respawn: k.respawn, ToggleDebug: k.toggleDebug}
+	k.respawn = false
+	k.toggleDebug = false
+
+	if k.pressed(Left) { //left wins if both are held
+		c.MoveX = -1
+	} else if k.pressed(Right) {
+		c.MoveX = 1
+	}
+	if k.pressed(Down) {
+		c.MoveY = -1
+	} else if k.pressed(Up) {
+		c.MoveY = 1
+	}
+	return c
+}
+
+/*
+	True if any key bound to the action is held down
+*/
+func (k *Keyboard) pressed(action string) bool {
+	for _, key := range k.keys[action].Keys {
+		if k.win.Pressed(key) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	True if any key bound to the action was pressed this frame
+*/
+func (k *Keyboard) justPressed(action string) bool {
+	for _, key := range k.keys[action].Keys {
+		if k.win.JustPressed(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package input
+
+import "GoGui/world"
+
+/*
+	Multi lets several inputs control the player at once, e.g. the keyboard and a gamepad. Movement comes from the
+	first input that is moving, and a press on any of them counts.
+*/
+type Multi []world.Input
+
+func (m Multi) Update() {
+	for _, in := range m {
+		in.Update()
+	}
+}
+
+func (m Multi) Controls() world.Controls {
+	var c world.Controls
+	for _, in := range m {
+		next := in.Controls() //every input has to be asked, or its presses would be held over to the next step
+		if c.MoveX == 0 && c.MoveY == 0 {
+			c.MoveX, c.MoveY = next.MoveX, next.MoveY
+		}
+		c.Respawn = c.Respawn || next.Respawn
+		c.ToggleDebug = c.ToggleDebug || next.ToggleDebug
+	}
+	return c
+}
@@ -0,0 +1,130 @@
+package level
+
+import (
+	"GoGui/assets"
+	"bufio"
+	"fmt"
+	"github.com/faiface/pixel"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// older levels were drawn in a 1300x1000 window, and positions in them depend on its center
+var legacyCenter = pixel.V(650, 500)
+
+const DefaultFile = "level.json" //the level played and edited when no other is named
+
+/*
+	Reads in a level file. If it's the default level and there isn't one, the level is imported from the layout.txt
+	and items.txt levels used to be kept in. Any other missing level is an error.
+*/
+func Load(path string) (*Level, error) {
+	lvl, err := Read(path)
+	if os.IsNotExist(err) && filepath.Clean(path) == DefaultFile {
+		log.Println(path + " not found, importing layout.txt and items.txt instead")
+		return ImportLegacy("layout.txt", "items.txt")
+	}
+	return lvl, err
+}
+
+/*
+	Builds a level out of the two text files levels used to be split across: layout.txt, with a barrier on each
+	line as "ax,ay,bx,by,", and items.txt, with an item on each line as "tag,x,y,". Malformed lines are left out
+	and listed in the level's Problems.
+*/
+func ImportLegacy(layoutPath string, itemsPath string) (*Level, error) {
+	lvl := New()
+	lvl.Spawn = legacyCenter
+	lvl.Background = legacyCenter.Sub(pixel.V(-80, -520)) //the background offset the game always used
+	lvl.BackgroundImage = "sprites/map.png"
+	lvl.OverlayImage = "sprites/mapoverlay.png"
+	lvl.Goal = GoalPickups
+
+	lvl.File = layoutPath
+	err := lvl.readLegacy(layoutPath, func(line int, lineElems []string) {
+		if lineElems[0] == "yscale" { //map setting rather than a barrier
+			if values, ok := lvl.parseRow(line, lineElems, true, "value"); ok {
+				if values[0] <= 0 {
+					lvl.problem(line, "value", "must be more than 0")
+					return
+				}
+				lvl.YScale = values[0]
+			}
+			return
+		}
+		if values, ok := lvl.parseRow(line, lineElems, false, "ax", "ay", "bx", "by"); ok {
+			lvl.Barriers = append(lvl.Barriers, Barrier{A: pixel.V(values[0], values[1]), B: pixel.V(values[2], values[3]), Line: line})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lvl.File = itemsPath //items come from here, so later problems with their tags point at the right file
+	err = lvl.readLegacy(itemsPath, func(line int, lineElems []string) {
+		tag := lineElems[0]
+		if values, ok := lvl.parseRow(line, lineElems, true, "x", "y"); ok {
+			lvl.Items = append(lvl.Items, Item{Tag: tag, Pos: pixel.V(values[0], values[1]), Line: line})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lvl, nil
+}
+
+/*
+	Hands every non-blank line of a legacy text file to row, split on commas with the trailing comma dropped
+*/
+func (lvl *Level) readLegacy(path string, row func(line int, lineElems []string)) error {
+	file, err := assets.Open(path) //open to read
+	if err != nil {
+		return err
+	}
+	defer file.Close() //ensure file is closed
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		lineElems := strings.Split(strings.TrimSuffix(text, ","), ",") //split on commas
+		row(line, lineElems)
+	}
+	return scanner.Err()
+}
+
+/*
+	Parses the numbers in a legacy row. If the row is named, e.g. by an item's tag, the name is skipped over
+	before the numbers. Every number must be there and be finite, otherwise a problem is recorded.
+*/
+func (lvl *Level) parseRow(line int, lineElems []string, named bool, fields ...string) ([]float64, bool) {
+	if named {
+		if lineElems[0] == "" {
+			lvl.problem(line, "tag", "missing")
+			return nil, false
+		}
+		lineElems = lineElems[1:]
+	}
+	if len(lineElems) != len(fields) {
+		lvl.problem(line, "", fmt.Sprintf("expected %d numbers (%s), got %d", len(fields), strings.Join(fields, ","), len(lineElems)))
+		return nil, false
+	}
+	values := make([]float64, len(fields))
+	for i, elem := range lineElems {
+		value, err := strconv.ParseFloat(strings.TrimSpace(elem), 64)
+		if err != nil {
+			lvl.problem(line, fields[i], fmt.Sprintf("%q isn't a number", elem))
+			return nil, false
+		}
+		values[i] = value
+	}
+	if !lvl.checkFinite(line, fields, values...) {
+		return nil, false
+	}
+	return values, true
+}
@@ -0,0 +1,18 @@
+package level
+
+import (
+	"os"
+	"testing"
+)
+
+/*
+	Only the default level falls back to the legacy files, so a misspelled level name is an error instead of
+	quietly playing the old map
+*/
+func TestLoadMissing(t *testing.T) {
+	for _, path := range []string{"cave.jsn", "levels/level.json"} {
+		if _, err := Load(path); !os.IsNotExist(err) {
+			t.Errorf("Load(%q) gave %v, want a not exist error", path, err)
+		}
+	}
+}
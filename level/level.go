@@ -0,0 +1,376 @@
+/*
+	Package level reads and writes level files. A level holds everything about one map: its barriers, its items,
+	where the player spawns and where the background goes. The game and the editor both use it.
+*/
+package level
+
+import (
+	"GoGui/assets"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/faiface/pixel"
+	"os"
+	"strings"
+)
+
+const Version = 2 //bump this whenever the format changes in a way older code can't read
+
+type Level struct {
+	Version     int    `json:"version"`
+	Name        string `json:"name"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+
+	Spawn      pixel.Vec `json:"spawn"`      //where the player starts and respawns
+	Background pixel.Vec `json:"background"` //where the center of the background image goes
+	YScale     float64   `json:"yscale"`     //squashes vertical movement to suit the map's isometric look, 1 for none
+
+	BackgroundImage string `json:"backgroundImage"` //drawn behind everything, none if empty
+	OverlayImage    string `json:"overlayImage"`    //drawn in front of everything, none if empty
+	Goal            Goal   `json:"goal"`            //what the player has to do to finish the level
+
+	Barriers []Barrier `json:"barriers"`
+	Items    []Item    `json:"items"`
+	Triggers []Trigger `json:"triggers,omitempty"`
+
+	File     string    `json:"-"` //the file the level was read from
+	Problems []Problem `json:"-"` //everything that was wrong with the file, the rows they were in are left out
+}
+
+/*
+	Barrier is a wall between two points that entities can't walk through
+*/
+type Barrier struct {
+	A     pixel.Vec `json:"a"`
+	B     pixel.Vec `json:"b"`
+	Layer string    `json:"layer,omitempty"` //which entities it blocks, see world.WallLayer. Empty for the wall layer
+
+	Line int `json:"-"` //line of the file it was read from, 0 if it wasn't read from one
+}
+
+/*
+	Item is an entity placed in the level, e.g. a ring or a goblin. Tag is the name of its archetype.
+*/
+type Item struct {
+	Tag string    `json:"tag"`
+	Pos pixel.Vec `json:"pos"`
+
+	Line int `json:"-"` //line of the file it was read from, 0 if it wasn't read from one
+}
+
+/*
+	Trigger is an area that doesn't block anything, but lets the game know when entities go in or out of it
+*/
+type Trigger struct {
+	Name string    `json:"name"`
+	Min  pixel.Vec `json:"min"`            //bottom left corner
+	Max  pixel.Vec `json:"max"`            //top right corner
+	Mask []string  `json:"mask,omitempty"` //layers of the entities that set it off, none for every entity
+
+	Line int `json:"-"` //line of the file it was read from, 0 if it wasn't read from one
+}
+
+/*
+	Goal is what finishes a level and moves the player on to the next one
+*/
+type Goal string
+
+const (
+	GoalNone    Goal = ""        //the level never ends
+	GoalPickups Goal = "pickups" //collect everything that can be picked up, e.g. every ring
+)
+
+/*
+	point is a pixel.Vec as it's written in a level file. The pointers are nil for coordinates that were left out,
+	so a missing one isn't mistaken for 0.
+*/
+type point struct {
+	X, Y *float64
+}
+
+/*
+	Creates an empty level in the current format
+*/
+func New() *Level {
+	return &Level{Version: Version, YScale: 1}
+}
+
+/*
+	Reads in a level file. Rows with something wrong with them are left out and listed in the level's Problems,
+	only a file that can't be read at all is an error.
+*/
+func Read(path string) (*Level, error) {
+	data, err := assets.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lvl := &Level{File: path}
+	if err := lvl.decode(data); err != nil {
+		return nil, err
+	}
+	if lvl.Version < 1 || lvl.Version > Version {
+		return nil, fmt.Errorf("%s: can't read level version %d, only up to %d", path, lvl.Version, Version)
+	}
+	if lvl.YScale == 0 { //a missing yscale means no squashing
+		lvl.YScale = 1
+	}
+	return lvl, nil
+}
+
+/*
+	Saves the level, replacing whatever was in the file before
+*/
+func (lvl *Level) Write(path string) error {
+	lvl.Version = Version
+	data, err := json.MarshalIndent(lvl, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+/*
+	Reads the level out of its JSON one field and one row at a time, so a bad row can be left out on its own and
+	every problem knows which line it's on
+*/
+func (lvl *Level) decode(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields() //a misspelled field would otherwise quietly become 0
+	fail := func(err error) error {
+		offset := dec.InputOffset()
+		if syntax, ok := err.(*json.SyntaxError); ok {
+			offset = syntax.Offset
+		}
+		return fmt.Errorf("%s:%d: %v", lvl.File, lineOf(data, offset), err)
+	}
+
+	if tok, err := dec.Token(); err != nil {
+		return fail(err)
+	} else if tok != json.Delim('{') {
+		return fail(errors.New("a level has to be a JSON object"))
+	}
+	fields := map[string]interface{}{ //everything that isn't a list of rows
+		"version":         &lvl.Version,
+		"name":            &lvl.Name,
+		"author":          &lvl.Author,
+		"description":     &lvl.Description,
+		"backgroundImage": &lvl.BackgroundImage,
+		"overlayImage":    &lvl.OverlayImage,
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		key, _ := tok.(string)
+		line := lineOf(data, dec.InputOffset())
+		switch key {
+		case "barriers":
+			err = lvl.decodeRows(dec, data, key, lvl.decodeBarrier)
+		case "items":
+			err = lvl.decodeRows(dec, data, key, lvl.decodeItem)
+		case "triggers":
+			err = lvl.decodeRows(dec, data, key, lvl.decodeTrigger)
+		case "spawn":
+			err = lvl.decodePoint(dec, key, line, &lvl.Spawn)
+		case "background":
+			err = lvl.decodePoint(dec, key, line, &lvl.Background)
+		case "yscale":
+			var yScale float64
+			if err = dec.Decode(&yScale); err != nil {
+				err = lvl.rowError(err, line, key)
+			} else if yScale <= 0 {
+				lvl.problem(line, key, "must be more than 0")
+			} else {
+				lvl.YScale = yScale
+			}
+		case "goal":
+			var goal Goal
+			if err = dec.Decode(&goal); err != nil {
+				err = lvl.rowError(err, line, key)
+			} else if goal != GoalNone && goal != GoalPickups {
+				lvl.problem(line, key, fmt.Sprintf("unknown goal %q", goal))
+			} else {
+				lvl.Goal = goal
+			}
+		default:
+			if field, ok := fields[key]; ok {
+				err = lvl.rowError(dec.Decode(field), line, key)
+			} else {
+				lvl.problem(line, key, "unknown field")
+				var skip json.RawMessage
+				err = dec.Decode(&skip)
+			}
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+	if _, err := dec.Token(); err != nil { //closing brace
+		return fail(err)
+	}
+	return nil
+}
+
+/*
+	Reads a list of rows, handing each one to row along with its name, e.g. "barriers[3]", and the line it starts on
+*/
+func (lvl *Level) decodeRows(dec *json.Decoder, data []byte, key string, row func(dec *json.Decoder, field string, line int) error) error {
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('[') {
+		return fmt.Errorf("%s has to be a list", key)
+	}
+	for i := 0; dec.More(); i++ {
+		line := lineOf(data, skipSeparators(data, dec.InputOffset()))
+		if err := row(dec, fmt.Sprintf("%s[%d]", key, i), line); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() //closing bracket
+	return err
+}
+
+func (lvl *Level) decodeBarrier(dec *json.Decoder, field string, line int) error {
+	var row struct {
+		A     *point `json:"a"`
+		B     *point `json:"b"`
+		Layer string `json:"layer"`
+	}
+	if err := dec.Decode(&row); err != nil {
+		return lvl.rowError(err, line, field)
+	}
+	if row.A == nil || row.B == nil {
+		lvl.problem(line, field, "a barrier needs both points, a and b")
+		return nil
+	}
+	a, okA := lvl.vec(*row.A, line, field+".a")
+	b, okB := lvl.vec(*row.B, line, field+".b")
+	if okA && okB {
+		lvl.Barriers = append(lvl.Barriers, Barrier{A: a, B: b, Layer: row.Layer, Line: line})
+	}
+	return nil
+}
+
+func (lvl *Level) decodeItem(dec *json.Decoder, field string, line int) error {
+	var row struct {
+		Tag *string `json:"tag"`
+		Pos *point  `json:"pos"`
+	}
+	if err := dec.Decode(&row); err != nil {
+		return lvl.rowError(err, line, field)
+	}
+	if row.Tag == nil || *row.Tag == "" {
+		lvl.problem(line, field+".tag", "missing")
+		return nil
+	}
+	if row.Pos == nil {
+		lvl.problem(line, field+".pos", "missing")
+		return nil
+	}
+	if pos, ok := lvl.vec(*row.Pos, line, field+".pos"); ok {
+		lvl.Items = append(lvl.Items, Item{Tag: *row.Tag, Pos: pos, Line: line})
+	}
+	return nil
+}
+
+func (lvl *Level) decodeTrigger(dec *json.Decoder, field string, line int) error {
+	var row struct {
+		Name *string  `json:"name"`
+		Min  *point   `json:"min"`
+		Max  *point   `json:"max"`
+		Mask []string `json:"mask"`
+	}
+	if err := dec.Decode(&row); err != nil {
+		return lvl.rowError(err, line, field)
+	}
+	if row.Name == nil || *row.Name == "" {
+		lvl.problem(line, field+".name", "missing")
+		return nil
+	}
+	if row.Min == nil || row.Max == nil {
+		lvl.problem(line, field, "a trigger needs both corners, min and max")
+		return nil
+	}
+	min, okMin := lvl.vec(*row.Min, line, field+".min")
+	max, okMax := lvl.vec(*row.Max, line, field+".max")
+	if okMin && okMax {
+		r := pixel.R(min.X, min.Y, max.X, max.Y).Norm() //corners the wrong way round still mean the same area
+		lvl.Triggers = append(lvl.Triggers, Trigger{Name: *row.Name, Min: r.Min, Max: r.Max, Mask: row.Mask, Line: line})
+	}
+	return nil
+}
+
+/*
+	Reads a single point, e.g. the spawn, into vec. vec is left alone if the point has a problem.
+*/
+func (lvl *Level) decodePoint(dec *json.Decoder, field string, line int, vec *pixel.Vec) error {
+	var p point
+	if err := dec.Decode(&p); err != nil {
+		return lvl.rowError(err, line, field)
+	}
+	if v, ok := lvl.vec(p, line, field); ok {
+		*vec = v
+	}
+	return nil
+}
+
+/*
+	Turns a point into a vector, recording a problem if either coordinate is missing
+*/
+func (lvl *Level) vec(p point, line int, field string) (pixel.Vec, bool) {
+	if p.X == nil {
+		lvl.problem(line, field+".X", "missing")
+		return pixel.ZV, false
+	}
+	if p.Y == nil {
+		lvl.problem(line, field+".Y", "missing")
+		return pixel.ZV, false
+	}
+	return pixel.V(*p.X, *p.Y), lvl.checkFinite(line, []string{field + ".X", field + ".Y"}, *p.X, *p.Y)
+}
+
+/*
+	Records a problem for an error decoding one row. Syntax errors are passed back, since there's no telling where
+	the next row starts after one.
+*/
+func (lvl *Level) rowError(err error, line int, field string) error {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, new(*json.SyntaxError)):
+		return err
+	case errors.As(err, &typeErr):
+		if typeErr.Field != "" {
+			field += "." + typeErr.Field
+		}
+		lvl.problem(line, field, fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value))
+	default:
+		lvl.problem(line, field, strings.TrimPrefix(err.Error(), "json: "))
+	}
+	return nil
+}
+
+/*
+	The line of data the byte at offset is on, counting from 1
+*/
+func lineOf(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return 1 + bytes.Count(data[:offset], []byte("\n"))
+}
+
+/*
+	Skips past the whitespace and comma between the end of one row and the start of the next
+*/
+func skipSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return offset
+}
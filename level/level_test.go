@@ -0,0 +1,151 @@
+package level
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+/*
+	Writes a level file into a temporary directory and reads it back in
+*/
+func readString(t *testing.T, data string) *Level {
+	path := filepath.Join(t.TempDir(), "level.json")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lvl, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lvl
+}
+
+/*
+	Each bad row is reported with the line it's on, the field that's wrong and why, and left out of the level
+*/
+func TestReadProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []Problem //File is filled in from wherever the test wrote the level
+	}{
+		{"clean", `{"version": 1,
+"barriers": [{"a": {"X": 1, "Y": 2}, "b": {"X": 3, "Y": 4}}],
+"items": [{"tag": "ring", "pos": {"X": 1, "Y": 2}}]}`, nil},
+		{"coordinate isn't a number", `{"version": 1, "barriers": [
+{"a": {"X": 1, "Y": 2}, "b": {"X": 3, "Y": 4}},
+{"a": {"X": "one", "Y": 2}, "b": {"X": 3, "Y": 4}}]}`,
+			[]Problem{{Line: 3, Field: "barriers[1].a.X", Msg: "expected float64, got string"}}},
+		{"barrier missing a point", `{"version": 1, "barriers": [
+{"a": {"X": 1, "Y": 2}}]}`,
+			[]Problem{{Line: 2, Field: "barriers[0]", Msg: "a barrier needs both points, a and b"}}},
+		{"missing coordinate", `{"version": 1, "barriers": [
+{"a": {"Y": 2}, "b": {"X": 3, "Y": 4}}]}`,
+			[]Problem{{Line: 2, Field: "barriers[0].a.X", Msg: "missing"}}},
+		{"unknown field in a row", `{"version": 1, "barriers": [
+{"a": {"X": 1, "Y": 2}, "b": {"X": 3, "Y": 4}, "c": 5}]}`,
+			[]Problem{{Line: 2, Field: "barriers[0]", Msg: `unknown field "c"`}}},
+		{"item without a tag", `{"version": 1, "items": [
+
+{"pos": {"X": 1, "Y": 2}}]}`,
+			[]Problem{{Line: 3, Field: "items[0].tag", Msg: "missing"}}},
+		{"item without a position", `{"version": 1, "items": [{"tag": "ring"}]}`,
+			[]Problem{{Line: 1, Field: "items[0].pos", Msg: "missing"}}},
+		{"unknown top level field", `{"version": 1,
+"colour": "red"}`,
+			[]Problem{{Line: 2, Field: "colour", Msg: "unknown field"}}},
+		{"unknown goal", `{"version": 1, "goal": "win"}`,
+			[]Problem{{Line: 1, Field: "goal", Msg: `unknown goal "win"`}}},
+		{"trigger without a name", `{"version": 2, "triggers": [
+{"min": {"X": 0, "Y": 0}, "max": {"X": 1, "Y": 1}}]}`,
+			[]Problem{{Line: 2, Field: "triggers[0].name", Msg: "missing"}}},
+	}
+	for _, test := range tests {
+		lvl := readString(t, test.data)
+		for i := range test.want {
+			test.want[i].File = lvl.File
+		}
+		if !reflect.DeepEqual(lvl.Problems, test.want) {
+			t.Errorf("%s: got problems %v, want %v", test.name, lvl.Problems, test.want)
+		}
+		if test.want != nil && len(lvl.Barriers)+len(lvl.Items)+len(lvl.Triggers) > 1 {
+			t.Errorf("%s: a bad row was kept", test.name)
+		}
+	}
+}
+
+/*
+	yscale has to be more than 0, and a missing one means no squashing
+*/
+func TestReadYScale(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    float64
+		problem bool
+	}{
+		{`{"version": 1}`, 1, false},
+		{`{"version": 1, "yscale": 0.5}`, 0.5, false},
+		{`{"version": 1, "yscale": 0}`, 1, true},
+		{`{"version": 1, "yscale": -2}`, 1, true},
+	}
+	for _, test := range tests {
+		lvl := readString(t, test.data)
+		if lvl.YScale != test.want {
+			t.Errorf("%s: yscale is %v, want %v", test.data, lvl.YScale, test.want)
+		}
+		want := []Problem(nil)
+		if test.problem {
+			want = []Problem{{lvl.File, 1, "yscale", "must be more than 0"}}
+		}
+		if !reflect.DeepEqual(lvl.Problems, want) {
+			t.Errorf("%s: got problems %v, want %v", test.data, lvl.Problems, want)
+		}
+	}
+}
+
+/*
+	Items nobody knows how to spawn are dropped and reported on their own line
+*/
+func TestCheckTags(t *testing.T) {
+	lvl := readString(t, `{"version": 1, "items": [
+{"tag": "ring", "pos": {"X": 1, "Y": 2}},
+{"tag": "dragon", "pos": {"X": 1, "Y": 2}},
+{"tag": "ring", "pos": {"X": 3, "Y": 4}}]}`)
+	lvl.CheckTags(func(tag string) bool { return tag == "ring" })
+	if want := []Problem{{lvl.File, 3, "tag", `unknown item "dragon"`}}; !reflect.DeepEqual(lvl.Problems, want) {
+		t.Errorf("got problems %v, want %v", lvl.Problems, want)
+	}
+	if len(lvl.Items) != 2 || lvl.Items[0].Tag != "ring" || lvl.Items[1].Tag != "ring" {
+		t.Errorf("kept items %v, want just the two rings", lvl.Items)
+	}
+}
+
+/*
+	-strict refuses to start if any level has a problem, and lets clean levels through
+*/
+func TestStrict(t *testing.T) {
+	clean := readString(t, `{"version": 1}`)
+	broken := readString(t, `{"version": 1, "yscale": 0, "goal": "win"}`)
+	if err := Strict(clean, clean); err != nil {
+		t.Errorf("refused clean levels: %v", err)
+	}
+	err := Strict(clean, broken)
+	if err == nil || err.Error() != "refusing to start with 2 problems in the levels (-strict)" {
+		t.Errorf("got %v, want a refusal counting 2 problems", err)
+	}
+}
+
+/*
+	A syntax error can't be skipped over, so it fails the whole read and says which line it's on
+*/
+func TestReadSyntaxError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "level.json")
+	if err := os.WriteFile(path, []byte("{\"version\": 1,\n\"barriers\": [}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil || err.Error()[:len(path)+2] != path+":2" {
+		t.Errorf("got %v, want an error on line 2", err)
+	}
+}
@@ -0,0 +1,36 @@
+package level
+
+import (
+	"GoGui/assets"
+	"encoding/json"
+	"fmt"
+)
+
+/*
+	Manifest lists the levels of the game in the order they're played
+*/
+type Manifest struct {
+	Levels []string `json:"levels"` //paths to level files
+}
+
+/*
+	Reads in a manifest file
+*/
+func ReadManifest(path string) (*Manifest, error) {
+	file, err := assets.Open(path) //open to read
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	manifest := &Manifest{}
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if len(manifest.Levels) == 0 {
+		return nil, fmt.Errorf("%s: there are no levels", path)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,73 @@
+package level
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+	Problem is something wrong with one row of a level file, e.g. a coordinate that isn't a number or an item
+	nobody knows how to spawn. The row it's in gets left out of the level.
+*/
+type Problem struct {
+	File  string
+	Line  int    //line of the file the row starts on
+	Field string //which part of the row is wrong, e.g. "barriers[3].a.X" or "x"
+	Msg   string
+}
+
+func (p Problem) Error() string {
+	if p.Field == "" {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Msg)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Field, p.Msg)
+}
+
+/*
+	Drops every item whose tag known says can't be spawned, recording a problem for each one
+*/
+func (lvl *Level) CheckTags(known func(tag string) bool) {
+	items := lvl.Items[:0]
+	for _, item := range lvl.Items {
+		if !known(item.Tag) {
+			lvl.problem(item.Line, "tag", fmt.Sprintf("unknown item %q", item.Tag))
+			continue
+		}
+		items = append(items, item)
+	}
+	lvl.Items = items
+}
+
+/*
+	Records a problem with the row on the given line of the file the level was read from
+*/
+func (lvl *Level) problem(line int, field string, msg string) {
+	lvl.Problems = append(lvl.Problems, Problem{lvl.File, line, field, msg})
+}
+
+/*
+	Makes sure none of a row's coordinates are NaN or infinite, recording a problem for the first one that is
+*/
+func (lvl *Level) checkFinite(line int, fields []string, values ...float64) bool {
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			lvl.problem(line, fields[i], "not a finite number")
+			return false
+		}
+	}
+	return true
+}
+
+/*
+	Refuses levels with anything wrong in them, for -strict. Gives back nil if every level is clean.
+*/
+func Strict(levels ...*Level) error {
+	problems := 0
+	for _, lvl := range levels {
+		problems += len(lvl.Problems)
+	}
+	if problems > 0 {
+		return fmt.Errorf("refusing to start with %d problems in the levels (-strict)", problems)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+/*
+	Package palette holds the colors the game and the editor both draw with, so a layer looks the same in each.
+*/
+package palette
+
+import (
+	"GoGui/world"
+	"golang.org/x/image/colornames"
+	"image/color"
+)
+
+/*
+	The color barriers on a layer are drawn in, so walls that only block some entities stand out
+*/
+func LayerColor(layer string) color.RGBA {
+	switch layer {
+	case "", world.WallLayer:
+		return colornames.Lime
+	case "player":
+		return colornames.Deepskyblue
+	case "goblin":
+		return colornames.Orangered
+	}
+	return colornames.Violet
+}
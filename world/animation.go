@@ -0,0 +1,207 @@
+package world
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+	Clip is a named animation on one of the spritesheets, e.g. "run_NW" or "spin"
+*/
+type Clip struct {
+	Sheet     string    //name of the spritesheet the frames are on
+	Frames    []int     //frames of the sheet, in the order they're played
+	Durations []float64 //seconds each frame shows for
+}
+
+/*
+	AnimState is one state of an animator, e.g. idle or running
+*/
+type AnimState struct {
+	Clip string `json:"clip"` //clip to play. "{dir}" is swapped for the way the entity faces, so "run_{dir}" plays "run_NW"
+	Loop bool   `json:"loop"` //play the clip over and over, otherwise stop on its last frame
+	Next string `json:"next"` //state to go to once a clip that doesn't loop has finished, none to stay on its last frame
+}
+
+/*
+	Transition moves an animator from one state to another when its condition is met
+*/
+type Transition struct {
+	From string `json:"from"` //state the animator has to be in, "*" for any
+	To   string `json:"to"`
+	When string `json:"when"` //"moving" or "still"
+}
+
+/*
+	Animator is a state machine that decides which clip an entity plays, and steps through that clip's frames
+*/
+type Animator struct {
+	Sheets      []string             `json:"sheets"` //sheets whose clips the entity can play
+	Start       string               `json:"start"`  //state a newly spawned entity is in
+	States      map[string]AnimState `json:"states"`
+	Transitions []Transition         `json:"transitions"` //checked in order, the first one that applies is taken
+
+	OnDone func(e *Entity, state string) `json:"-"` //called when a clip that doesn't loop finishes, nil for nothing
+
+	State string `json:"-"` //state the animator is in
+	Frame int    `json:"-"` //frame of the sheet being shown
+
+	clips    map[string]Clip //every clip on Sheets by name, shared by all entities of the same kind
+	clipName string          //clip being played, "" to start the state's clip over
+	index    int             //how far through the clip it is
+	time     float64         //seconds spent on the current frame so far
+	done     bool            //whether a clip that doesn't loop has finished
+}
+
+// the suffixes "{dir}" can be swapped for, one per row of a directional sheet
+var clipDirs = []string{"S", "SW", "W", "NW", "N"}
+
+/*
+	Goes into a state and starts its clip from the beginning
+*/
+func (anim *Animator) Enter(state string) {
+	anim.State = state
+	anim.clipName = ""
+}
+
+/*
+	Moves on to another frame of the current clip without waiting, e.g. so items don't all animate in step
+*/
+func (anim *Animator) Seek(index int) {
+	clip := anim.clips[anim.clipName]
+	anim.index = index % len(clip.Frames)
+	anim.time = 0
+	anim.Frame = clip.Frames[anim.index]
+}
+
+/*
+	How many frames the clip being played has
+*/
+func (anim *Animator) Len() int {
+	return len(anim.clips[anim.clipName].Frames)
+}
+
+/*
+	Takes any transition whose condition is met, then advances the clip by dt seconds. Also points the entity's
+	sprite at the sheet the clip is on.
+*/
+func (anim *Animator) Update(e *Entity, dt float64) {
+	for _, t := range anim.Transitions {
+		if (t.From == anim.State || t.From == "*") && t.To != anim.State && anim.condition(t.When, e) {
+			anim.Enter(t.To)
+			break
+		}
+	}
+
+	state := anim.States[anim.State]
+	name := strings.ReplaceAll(state.Clip, "{dir}", e.Dir.ClipSuffix())
+	if name != anim.clipName { //a new clip, start it from the top
+		anim.clipName = name
+		anim.index = 0
+		anim.time = 0
+		anim.done = false
+	}
+	clip := anim.clips[name]
+
+	finished := false
+	anim.time += dt
+	for !anim.done && anim.time >= clip.Durations[anim.index] {
+		anim.time -= clip.Durations[anim.index]
+		anim.index++
+		if anim.index == len(clip.Frames) {
+			if state.Loop {
+				anim.index = 0
+			} else { //hold the last frame
+				anim.index--
+				anim.done = true
+				finished = true
+			}
+		}
+	}
+	anim.Frame = clip.Frames[anim.index]
+	if e.Sprite != nil {
+		e.Sprite.Sheet = clip.Sheet
+	}
+
+	if finished {
+		if anim.OnDone != nil {
+			anim.OnDone(e, anim.State)
+		}
+		if state.Next != "" {
+			anim.Enter(state.Next)
+		}
+	}
+}
+
+/*
+	Whether a transition's condition holds for the entity
+*/
+func (anim *Animator) condition(when string, e *Entity) bool {
+	switch when {
+	case "moving":
+		return e.Moving
+	case "still":
+		return !e.Moving
+	}
+	return false
+}
+
+/*
+	Finds the clips on the animator's sheets and makes sure every state, transition and clip it refers to exists. A
+	sheet whose picture is missing has nil clips, and any clip that isn't found is then played as a single frame of
+	that sheet's placeholder.
+*/
+func (anim *Animator) link(clips map[string]map[string]Clip) error {
+	anim.clips = map[string]Clip{}
+	missing := "" //a sheet that's missing, to put placeholder clips on
+	for _, sheet := range anim.Sheets {
+		sheetClips, ok := clips[sheet]
+		if !ok {
+			return fmt.Errorf("the sheet %q isn't defined", sheet)
+		}
+		if sheetClips == nil && missing == "" {
+			missing = sheet
+		}
+		for name, clip := range sheetClips {
+			if _, ok := anim.clips[name]; ok {
+				return fmt.Errorf("more than one of its sheets has a clip called %q", name)
+			}
+			anim.clips[name] = clip
+		}
+	}
+
+	if _, ok := anim.States[anim.Start]; !ok {
+		return fmt.Errorf("its start state %q isn't one of its states", anim.Start)
+	}
+	for name, state := range anim.States {
+		names := []string{state.Clip}
+		if strings.Contains(state.Clip, "{dir}") {
+			names = nil
+			for _, dir := range clipDirs {
+				names = append(names, strings.ReplaceAll(state.Clip, "{dir}", dir))
+			}
+		}
+		for _, clip := range names {
+			if _, ok := anim.clips[clip]; !ok && missing != "" {
+				anim.clips[clip] = Clip{missing, []int{0}, []float64{1}}
+			} else if !ok {
+				return fmt.Errorf("state %q plays the clip %q, which none of its sheets have", name, clip)
+			}
+		}
+		if _, ok := anim.States[state.Next]; state.Next != "" && !ok {
+			return fmt.Errorf("state %q goes on to %q, which isn't one of its states", name, state.Next)
+		}
+	}
+	for _, t := range anim.Transitions {
+		if _, ok := anim.States[t.From]; t.From != "*" && !ok {
+			return fmt.Errorf("there's a transition from %q, which isn't one of its states", t.From)
+		}
+		if _, ok := anim.States[t.To]; !ok {
+			return fmt.Errorf("there's a transition to %q, which isn't one of its states", t.To)
+		}
+		if t.When != "moving" && t.When != "still" {
+			return fmt.Errorf("there's a transition when %q, it has to be \"moving\" or \"still\"", t.When)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package world
+
+import (
+	"testing"
+)
+
+/*
+	An animator for a hero that walks, idles, and can be told to attack. Walking has a clip for each direction,
+	attacking plays once and goes back to idle.
+*/
+func heroAnimator(t *testing.T) *Animator {
+	clips := map[string]Clip{
+		"attack": {"hero", []int{10, 11, 12}, []float64{0.125, 0.125, 0.25}},
+		"idle":   {"hero", []int{20}, []float64{0.5}},
+	}
+	for i, dir := range clipDirs {
+		clips["walk_"+dir] = Clip{"hero", []int{2 * i, 2*i + 1}, []float64{0.25, 0.25}}
+	}
+	anim := &Animator{
+		Sheets: []string{"hero"},
+		Start:  "idle",
+		States: map[string]AnimState{
+			"idle":   {Clip: "idle", Loop: true},
+			"walk":   {Clip: "walk_{dir}", Loop: true},
+			"attack": {Clip: "attack", Next: "idle"},
+		},
+		Transitions: []Transition{
+			{From: "idle", To: "walk", When: "moving"},
+			{From: "walk", To: "idle", When: "still"},
+		},
+	}
+	if err := anim.link(map[string]map[string]Clip{"hero": clips}); err != nil {
+		t.Fatal(err)
+	}
+	anim.Enter(anim.Start)
+	return anim
+}
+
+/*
+	Walking, stopping to attack, and going back to idle once the attack is over
+*/
+func TestAnimatorWalkAttackIdle(t *testing.T) {
+	anim := heroAnimator(t)
+	var done []string
+	anim.OnDone = func(e *Entity, state string) {
+		done = append(done, state)
+	}
+	e := &Entity{Dir: S, Sprite: &Sprite{}, Animator: anim}
+
+	steps := []struct {
+		name   string
+		moving bool
+		dir    Direction
+		enter  string  //state to go into before updating, "" to leave it to the transitions
+		dt     float64 //seconds to update by
+		state  string  //state it should end up in
+		frame  int     //frame it should end up showing
+		done   int     //how many clips should have finished so far
+	}{
+		{"idle to start with", false, S, "", 0, "idle", 20, 0},
+		{"still idle", false, S, "", 1, "idle", 20, 0},
+		{"starts walking", true, S, "", 0, "walk", 0, 0},
+		{"second frame of the walk", true, S, "", 0.25, "walk", 1, 0},
+		{"the walk loops", true, S, "", 0.25, "walk", 0, 0},
+		{"turning starts the new direction's clip", true, W, "", 0.125, "walk", 4, 0},
+		{"facing right reuses the left clip", true, E, "", 0, "walk", 4, 0},
+		{"attacks", true, E, "attack", 0, "attack", 10, 0},
+		{"moving doesn't interrupt the attack", true, E, "", 0.125, "attack", 11, 0},
+		{"last frame of the attack", false, E, "", 0.125, "attack", 12, 0},
+		{"the attack finishes and goes on to idle", false, E, "", 0.25, "idle", 12, 1},
+		{"idle plays its clip", false, E, "", 0, "idle", 20, 1},
+		{"walks again", true, N, "", 0.25, "walk", 9, 1},
+		{"stops", false, N, "", 0, "idle", 20, 1},
+	}
+	for _, step := range steps {
+		e.Moving, e.Dir = step.moving, step.dir
+		if step.enter != "" {
+			anim.Enter(step.enter)
+		}
+		anim.Update(e, step.dt)
+		if anim.State != step.state || anim.Frame != step.frame || len(done) != step.done {
+			t.Fatalf("%s: in %q on frame %d with %d finished, want %q on frame %d with %d finished", step.name,
+				anim.State, anim.Frame, len(done), step.state, step.frame, step.done)
+		}
+		if e.Sprite.Sheet != "hero" {
+			t.Fatalf("%s: sprite is on sheet %q, want hero", step.name, e.Sprite.Sheet)
+		}
+	}
+	if done[0] != "attack" {
+		t.Errorf("OnDone was told %q finished, want attack", done[0])
+	}
+}
+
+/*
+	A clip that doesn't loop and has nowhere to go next holds its last frame, and only says it's finished once
+*/
+func TestAnimatorOneShotHolds(t *testing.T) {
+	anim := heroAnimator(t)
+	anim.States["attack"] = AnimState{Clip: "attack"}
+	finished := 0
+	anim.OnDone = func(e *Entity, state string) {
+		finished++
+	}
+	e := &Entity{Dir: S}
+	anim.Enter("attack")
+	anim.Update(e, 5) //far longer than the clip
+	for i := 0; i < 10; i++ {
+		anim.Update(e, 0.25)
+	}
+	if anim.State != "attack" || anim.Frame != 12 || finished != 1 {
+		t.Errorf("in %q on frame %d, finished %d times, want to hold frame 12 of attack having finished once",
+			anim.State, anim.Frame, finished)
+	}
+
+	anim.Enter("attack") //going into the state again plays it again
+	anim.Update(e, 0)
+	if anim.Frame != 10 {
+		t.Errorf("entering attack again showed frame %d, want it to start over on 10", anim.Frame)
+	}
+	anim.Update(e, 0.5)
+	if finished != 2 {
+		t.Errorf("finished %d times after playing attack again, want 2", finished)
+	}
+}
+
+/*
+	link won't accept an animator that plays a clip none of its sheets have
+*/
+func TestAnimatorLinkMissingClip(t *testing.T) {
+	anim := &Animator{Sheets: []string{"hero"}, Start: "fly", States: map[string]AnimState{"fly": {Clip: "fly_{dir}"}}}
+	clips := map[string]map[string]Clip{"hero": {"fly_S": {"hero", []int{0}, []float64{1}}}}
+	if err := anim.link(clips); err == nil {
+		t.Error("linked an animator that's missing most of its fly clips")
+	}
+}
@@ -0,0 +1,158 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+	"math"
+)
+
+/*
+	Collects every pickup the player is touching
+*/
+func (w *World) collectPickups() {
+	player := w.Player.Collider
+	w.nearEntities = w.index().entitiesNear(player.Circle, w.nearEntities[:0])
+	for _, e := range w.nearEntities {
+		if e.Pickup == nil || e.Collider == nil {
+			continue
+		}
+		//check if distance apart greater than or equal to sum of the two radii
+		if distance(player.Center, e.Collider.Center) <= player.Radius+e.Collider.Radius {
+			w.remove(e) //collect it
+			w.Score += e.Pickup.Score
+		}
+	}
+}
+
+/*
+	Takes an entity out of the world, and out of the grid
+*/
+func (w *World) remove(e *Entity) {
+	for i, other := range w.Entities {
+		if other == e {
+			w.Entities[i] = w.Entities[len(w.Entities)-1]
+			w.Entities = w.Entities[:len(w.Entities)-1]
+			break
+		}
+	}
+	w.index().remove(e)
+}
+
+/*
+	Contact is how a circle overlaps a barrier
+*/
+type Contact struct {
+	Normal pixel.Vec //unit vector pointing from the barrier out towards the circle's center
+	Depth  float64   //how far the circle has to move along Normal to stop overlapping
+}
+
+const slideIterations = 4 //times per move an entity is pushed out of barriers, so it settles into corners
+
+const maxStepFraction = 0.5 //longest part of a move, as a fraction of the collider's radius, taken before checking for barriers
+
+/*
+	Moves an entity by delta, sliding it along any barrier it runs into instead of stopping dead. Pushing it back out
+	along the barrier's normal takes away the part of the move that went into the barrier and keeps the part along
+	it. A long move is split into steps shorter than the collider's radius, so however big dt is the entity can't
+	jump clean over a barrier. Gives back how far the entity really moved.
+*/
+func (w *World) move(e *Entity, delta pixel.Vec) pixel.Vec {
+	start := e.Pos
+	if e.Collider == nil {
+		e.Pos = e.Pos.Add(delta)
+		return delta
+	}
+
+	steps := 1
+	if maxStep := e.Collider.Radius * maxStepFraction; maxStep > 0 {
+		steps = int(math.Max(1, math.Ceil(delta.Len()/maxStep)))
+	}
+	step := delta.Scaled(1 / float64(steps))
+	for s := 0; s < steps; s++ {
+		e.Pos = e.Pos.Add(step)
+		e.Collider.Center = e.Pos.Add(e.Collider.Offset)
+		for i := 0; i < slideIterations; i++ { //being pushed out of one wall of a corner can push it into the other
+			if w.resolveCollisions(e) == 0 {
+				break
+			}
+		}
+	}
+	return e.Pos.Sub(start)
+}
+
+/*
+	Tests collisions of an entity against barriers, and pushes it back out of any it's crossed. Gives back how many
+	barriers it was touching.
+*/
+func (w *World) resolveCollisions(subject *Entity) int {
+	totalCollisions := 0
+	w.nearBarriers = w.index().barriersNear(subject.Collider.Circle, w.nearBarriers[:0])
+	for _, i := range w.nearBarriers {
+		line := w.Barriers[i]
+		if !subject.Collider.BlockedBy(line.Layer) {
+			continue
+		}
+		contact, ok := subject.Collider.Collide(line)
+		if !ok {
+			continue
+		}
+		totalCollisions++
+		push := contact.Normal.Scaled(contact.Depth)
+		subject.Pos = subject.Pos.Add(push)
+		subject.Collider.Center = subject.Collider.Center.Add(push) //so the next barrier sees where it's been pushed to
+	}
+	return totalCollisions
+}
+
+/*
+	Finds how the circle overlaps a barrier, if it does. The barrier is treated as having rounded ends, so a circle
+	touching one of its endpoints is pushed straight away from that point.
+*/
+func (c Circle) Collide(line Line) (Contact, bool) {
+	closest := closestPoint(line, c.Center)
+	offset := c.Center.Sub(closest)
+	dist := offset.Len()
+	if dist >= c.Radius {
+		return Contact{}, false
+	}
+
+	var normal pixel.Vec
+	if dist > 0 {
+		normal = offset.Scaled(1 / dist)
+	} else if along := line.B.Sub(line.A); along != pixel.ZV { //center is right on the line, pick a side
+		normal = along.Unit().Normal()
+	} else {
+		normal = pixel.V(0, 1)
+	}
+	return Contact{normal, c.Radius - dist}, true
+}
+
+/*
+	Whether the circle overlaps a rectangle at all
+*/
+func (c Circle) Overlaps(r pixel.Rect) bool {
+	closest := pixel.V(math.Max(r.Min.X, math.Min(r.Max.X, c.Center.X)), math.Max(r.Min.Y, math.Min(r.Max.Y, c.Center.Y)))
+	return distance(closest, c.Center) < c.Radius
+}
+
+/*
+	The point on a barrier closest to p, which is one of its endpoints if p is past either end
+*/
+func closestPoint(line Line, p pixel.Vec) pixel.Vec {
+	along := line.B.Sub(line.A)
+	lenSq := along.Dot(along)
+	if lenSq == 0 { //both ends in the same place
+		return line.A
+	}
+	t := p.Sub(line.A).Dot(along) / lenSq //how far along the line p is, 0 at A and 1 at B
+	t = math.Max(0, math.Min(1, t))
+	return line.A.Add(along.Scaled(t))
+}
+
+/*
+	Finds the in world distance between two points
+*/
+func distance(point1 pixel.Vec, point2 pixel.Vec) float64 {
+	// distance = √(x₂ - x₁)² + (y₂ - y₁)²,
+	dist := math.Sqrt(math.Pow(point1.X-point2.X, 2) + math.Pow(point1.Y-point2.Y, 2))
+	return dist
+}
@@ -0,0 +1,224 @@
+package world
+
+import (
+	"GoGui/level"
+	"github.com/faiface/pixel"
+	"math"
+	"testing"
+)
+
+/*
+	Every barrier in layout.txt, exactly as it's drawn in the file
+*/
+func layoutBarriers(t *testing.T) []Line {
+	lvl, err := level.ImportLegacy("../layout.txt", "../items.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lvl.Problems) > 0 {
+		t.Fatalf("layout.txt has problems: %v", lvl.Problems)
+	}
+	var lines []Line
+	for _, bar := range lvl.Barriers {
+		lines = append(lines, Line{A: bar.A, B: bar.B, Layer: bar.Layer})
+	}
+	if len(lines) == 0 {
+		t.Fatal("layout.txt has no barriers")
+	}
+	return lines
+}
+
+func near(a, b pixel.Vec) bool {
+	return math.Abs(a.X-b.X) < 1e-6 && math.Abs(a.Y-b.Y) < 1e-6
+}
+
+func TestCollideShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   Line
+		center pixel.Vec
+		hit    bool
+		normal pixel.Vec
+		depth  float64
+	}{
+		{"horizontal from above", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(50, 6), true, pixel.V(0, 1), 4},
+		{"horizontal from below", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(50, -6), true, pixel.V(0, -1), 4},
+		{"vertical from the right", Line{A: pixel.V(0, 0), B: pixel.V(0, 100)}, pixel.V(3, 40), true, pixel.V(1, 0), 7},
+		{"vertical drawn upwards", Line{A: pixel.V(0, 100), B: pixel.V(0, 0)}, pixel.V(-8, 40), true, pixel.V(-1, 0), 2},
+		{"diagonal", Line{A: pixel.V(0, 0), B: pixel.V(100, 100)}, pixel.V(50, 56), true, pixel.V(-1, 1).Unit(), 10 - 6/math.Sqrt2},
+		{"outside the cap at end A", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(-6, 8), false, pixel.ZV, 0},
+		{"cap at end A", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(-3, 4), true, pixel.V(-0.6, 0.8), 5},
+		{"cap at end B", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(106, 0), true, pixel.V(1, 0), 4},
+		{"on the line", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(50, 0), true, pixel.V(0, 1), 10},
+		{"single point", Line{A: pixel.V(5, 5), B: pixel.V(5, 5)}, pixel.V(5, 1), true, pixel.V(0, -1), 6},
+		{"just touching", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(50, 10), false, pixel.ZV, 0},
+		{"far away", Line{A: pixel.V(0, 0), B: pixel.V(100, 0)}, pixel.V(50, 500), false, pixel.ZV, 0},
+	}
+	for _, test := range tests {
+		contact, hit := Circle{test.center, 10}.Collide(test.line)
+		if hit != test.hit {
+			t.Errorf("%s: hit = %v, want %v", test.name, hit, test.hit)
+			continue
+		}
+		if !hit {
+			continue
+		}
+		if !near(contact.Normal, test.normal) {
+			t.Errorf("%s: normal = %v, want %v", test.name, contact.Normal, test.normal)
+		}
+		if math.Abs(contact.Depth-test.depth) > 1e-6 {
+			t.Errorf("%s: depth = %v, want %v", test.name, contact.Depth, test.depth)
+		}
+	}
+}
+
+/*
+	A circle overlapping the middle of any barrier in the level is pushed straight out of it, whichever side it's on
+	and however the barrier is angled
+*/
+func TestCollideLayoutSides(t *testing.T) {
+	for i, line := range layoutBarriers(t) {
+		mid := line.A.Add(line.B).Scaled(0.5)
+		perp := line.B.Sub(line.A).Unit().Normal()
+		for _, side := range []float64{1, -1} {
+			normal := perp.Scaled(side)
+			circ := Circle{mid.Add(normal.Scaled(4)), 10}
+			contact, hit := circ.Collide(line)
+			if !hit {
+				t.Errorf("barrier %d %v: missed a circle overlapping its middle", i, line)
+				continue
+			}
+			if !near(contact.Normal, normal) || math.Abs(contact.Depth-6) > 1e-6 {
+				t.Errorf("barrier %d %v: contact %+v, want normal %v and depth 6", i, line, contact, normal)
+			}
+		}
+	}
+}
+
+/*
+	A circle just past either end of any barrier in the level is pushed away from that endpoint
+*/
+func TestCollideLayoutEndpoints(t *testing.T) {
+	for i, line := range layoutBarriers(t) {
+		along := line.B.Sub(line.A).Unit()
+		for _, end := range []struct {
+			point pixel.Vec
+			out   pixel.Vec
+		}{{line.A, along.Scaled(-1)}, {line.B, along}} {
+			out := end.out.Add(end.out.Normal()).Unit() //off the end and to one side, so only the cap is touched
+			circ := Circle{end.point.Add(out.Scaled(7)), 10}
+			contact, hit := circ.Collide(line)
+			if !hit {
+				t.Errorf("barrier %d %v: missed a circle overlapping its end %v", i, line, end.point)
+				continue
+			}
+			if !near(contact.Normal, out) || math.Abs(contact.Depth-3) > 1e-6 {
+				t.Errorf("barrier %d %v: contact %+v at end %v, want normal %v and depth 3", i, line, contact, end.point, out)
+			}
+		}
+	}
+}
+
+/*
+	After being pushed out of a barrier, an entity is left touching it and no longer overlapping
+*/
+func TestResolveLayout(t *testing.T) {
+	for i, line := range layoutBarriers(t) {
+		w := &World{Barriers: []Line{line}}
+		for _, start := range []pixel.Vec{
+			line.A.Add(line.B).Scaled(0.5).Add(pixel.V(3, 2)),
+			line.A.Add(pixel.V(-2, 1)),
+			line.B.Add(pixel.V(1, -4)),
+		} {
+			e := &Entity{Pos: start, Collider: &Collider{Circle: Circle{Radius: 10}, Offset: pixel.V(0, -5)}}
+			e.Collider.Center = e.Pos.Add(e.Collider.Offset)
+			if w.resolveCollisions(e) != 1 {
+				t.Errorf("barrier %d %v: entity at %v wasn't touching it", i, line, start)
+				continue
+			}
+			if !near(e.Collider.Center, e.Pos.Add(e.Collider.Offset)) {
+				t.Errorf("barrier %d %v: collider at %v was left behind its entity at %v", i, line, e.Collider.Center, e.Pos)
+			}
+			dist := e.Collider.Center.Sub(closestPoint(line, e.Collider.Center)).Len()
+			if math.Abs(dist-10) > 1e-6 {
+				t.Errorf("barrier %d %v: entity from %v ended up %v from it, want 10", i, line, start, dist)
+			}
+			if contact, hit := e.Collider.Collide(line); hit && contact.Depth > 1e-6 {
+				t.Errorf("barrier %d %v: entity from %v was still overlapping it after being pushed out", i, line, start)
+			}
+		}
+	}
+}
+
+/*
+	Moving into a wall at an angle keeps the part of the move along the wall, and none of the part into it
+*/
+func TestMoveSlides(t *testing.T) {
+	w := &World{Barriers: []Line{{A: pixel.V(-100, 0), B: pixel.V(100, 0)}}}
+	e := &Entity{Pos: pixel.V(0, 10), Collider: &Collider{Circle: Circle{Radius: 10}}}
+	moved := w.move(e, pixel.V(3, -4))
+	if !near(moved, pixel.V(3, 0)) || !near(e.Pos, pixel.V(3, 10)) {
+		t.Errorf("moved %v to %v, want to slide (3, 0) to (3, 10)", moved, e.Pos)
+	}
+}
+
+/*
+	Pushing into a corner leaves the entity clear of both of its walls
+*/
+func TestMoveCorner(t *testing.T) {
+	w := &World{Barriers: []Line{{A: pixel.V(0, 0), B: pixel.V(100, 0)}, {A: pixel.V(0, 0), B: pixel.V(20, 100)}}}
+	e := &Entity{Pos: pixel.V(30, 15), Collider: &Collider{Circle: Circle{Radius: 10}}}
+	for i := 0; i < 20; i++ {
+		w.move(e, pixel.V(-3, -3))
+	}
+	for _, line := range w.Barriers {
+		if contact, hit := e.Collider.Collide(line); hit && contact.Depth > 1e-3 {
+			t.Errorf("ended up at %v, %v into %v", e.Pos, contact.Depth, line)
+		}
+	}
+}
+
+/*
+	However far an entity moves in one go, it can't end up on the other side of any barrier in the level
+*/
+func TestMoveNoTunnelling(t *testing.T) {
+	for i, line := range layoutBarriers(t) {
+		w := &World{Barriers: []Line{line}}
+		mid := line.A.Add(line.B).Scaled(0.5)
+		normal := line.B.Sub(line.A).Unit().Normal()
+		for _, dist := range []float64{15, 100, 5000} {
+			e := &Entity{Pos: mid.Add(normal.Scaled(11)), Collider: &Collider{Circle: Circle{Radius: 10}}}
+			w.move(e, normal.Scaled(-dist))
+			if side := e.Pos.Sub(mid).Dot(normal); side < 10-1e-6 {
+				t.Errorf("barrier %d %v: moving %v through it left the entity at %v, %v from it", i, line, dist, e.Pos, side)
+			}
+		}
+	}
+}
+
+/*
+	A barrier only blocks the colliders whose mask has its layer, and colliders without a mask only hit walls
+*/
+func TestMoveLayers(t *testing.T) {
+	w := &World{Barriers: []Line{
+		{A: pixel.V(-100, 0), B: pixel.V(100, 0), Layer: "goblin"},
+		{A: pixel.V(-100, -50), B: pixel.V(100, -50)},
+	}}
+	tests := []struct {
+		name string
+		mask []string
+		want float64 //where it should stop
+	}{
+		{"no mask", nil, -40},
+		{"goblin", []string{WallLayer, "goblin"}, 10},
+		{"player", []string{WallLayer, "player"}, -40},
+		{"ghost", []string{}, -100},
+	}
+	for _, test := range tests {
+		e := &Entity{Pos: pixel.V(0, 20), Collider: &Collider{Circle: Circle{Radius: 10}, Mask: test.mask}}
+		w.move(e, pixel.V(0, -120))
+		if !near(e.Pos, pixel.V(0, test.want)) {
+			t.Errorf("%s: ended up at %v, want (0, %v)", test.name, e.Pos, test.want)
+		}
+	}
+}
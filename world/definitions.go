@@ -0,0 +1,125 @@
+package world
+
+import (
+	"GoGui/assets"
+	"encoding/json"
+	"fmt"
+	"github.com/faiface/pixel"
+	"log"
+	"os"
+)
+
+/*
+	Definitions describe every kind of entity and spritesheet in the game. They're read from a JSON file so new
+	types can be added and old ones tuned without recompiling.
+*/
+type Definitions struct {
+	Sheets     map[string]SheetDef  `json:"sheets"`
+	Archetypes map[string]Archetype `json:"archetypes"` //kinds of entity by tag, e.g. "ring"
+}
+
+/*
+	SheetDef says where a spritesheet is. How it's cut into frames is in the sidecar file next to it.
+*/
+type SheetDef struct {
+	Path string `json:"path"`
+}
+
+/*
+	Archetype is the template every entity of one kind is made from. Leaving a component out means entities of
+	this kind don't have it.
+*/
+type Archetype struct {
+	Speed    float64   `json:"speed"`
+	Sprite   *Sprite   `json:"sprite"`
+	Animator *Animator `json:"animator"`
+	Collider *Collider `json:"collider"`
+	AI       *AI       `json:"ai"`
+	Pickup   *Pickup   `json:"pickup"`
+}
+
+/*
+	Reads in the definitions file
+*/
+func ReadDefinitions(path string) (*Definitions, error) {
+	file, err := assets.Open(path) //open to read
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	defs := &Definitions{}
+	if err := json.NewDecoder(file).Decode(defs); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if player, ok := defs.Archetypes["player"]; !ok {
+		return nil, fmt.Errorf("%s: there is no player archetype", path)
+	} else if player.Sprite == nil || player.Animator == nil || player.Collider == nil {
+		return nil, fmt.Errorf("%s: the player needs a sprite, an animator and a collider", path)
+	}
+
+	clips := map[string]map[string]Clip{} //every clip on every sheet, by sheet name and then clip name
+	for name, sheet := range defs.Sheets {
+		atlas, err := assets.ReadAtlas(sheet.Path)
+		if os.IsNotExist(err) { //drawn as a checkerboard, its clips are made up when the animators are linked
+			log.Println("using a placeholder for " + sheet.Path + ": " + err.Error())
+			clips[name] = nil
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		clips[name] = map[string]Clip{}
+		for clipName, anim := range atlas.Animations {
+			clips[name][clipName] = Clip{name, anim.Frames, anim.Durations}
+		}
+	}
+	for tag, arch := range defs.Archetypes { //make sure every sprite can actually be drawn
+		if arch.Animator != nil {
+			if err := arch.Animator.link(clips); err != nil {
+				return nil, fmt.Errorf("%s: %s's animator: %v", path, tag, err)
+			}
+			continue
+		}
+		if arch.Sprite == nil {
+			continue
+		}
+		if _, ok := defs.Sheets[arch.Sprite.Sheet]; !ok {
+			return nil, fmt.Errorf("%s: %s uses the sheet %q, which isn't defined", path, tag, arch.Sprite.Sheet)
+		}
+	}
+	return defs, nil
+}
+
+/*
+	Makes a new entity of the given kind at pos. Every component is copied so entities don't share state.
+*/
+func (defs *Definitions) Spawn(tag string, pos pixel.Vec) (*Entity, error) {
+	arch, ok := defs.Archetypes[tag]
+	if !ok {
+		return nil, fmt.Errorf("there is no archetype for %q", tag)
+	}
+	e := &Entity{Tag: tag, Pos: pos, PrevPos: pos, Scale: pixel.V(1, 1), Dir: S, Speed: arch.Speed}
+	if arch.Sprite != nil {
+		sprite := *arch.Sprite
+		e.Sprite = &sprite
+	}
+	if arch.Animator != nil {
+		anim := *arch.Animator
+		e.Animator = &anim
+		anim.Enter(anim.Start)
+		anim.Update(e, 0) //show the start state's first frame straight away
+	}
+	if arch.Collider != nil {
+		col := *arch.Collider
+		col.Center = pos.Add(col.Offset)
+		e.Collider = &col
+	}
+	if arch.AI != nil {
+		e.AI = &AI{true, 10} //a fresh brain, ready to follow the player
+	}
+	if arch.Pickup != nil {
+		pickup := *arch.Pickup
+		e.Pickup = &pickup
+	}
+	return e, nil
+}
@@ -0,0 +1,83 @@
+package world
+
+import (
+	"encoding/json"
+	"github.com/faiface/pixel"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+	Writes out the game's own entities.json with some sheets or archetypes added or replaced, and gives back where it
+	went. Changes are by section and then by name, e.g. {"archetypes": {"wisp": ...}}.
+*/
+func writeDefinitions(t *testing.T, changes map[string]map[string]string) string {
+	data, err := os.ReadFile("../entities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	for section, entries := range changes {
+		for name, entry := range entries {
+			file[section][name] = json.RawMessage(entry)
+		}
+	}
+	if data, err = json.Marshal(file); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "entities.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadDefinitionsColliderOnly(t *testing.T) {
+	defs, err := ReadDefinitions(writeDefinitions(t, map[string]map[string]string{"archetypes": {"wisp": `{"collider": {"radius": 5}}`}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := defs.Spawn("wisp", pixel.ZV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Sprite != nil || e.Animator != nil || e.Collider == nil || e.Collider.Radius != 5 {
+		t.Errorf("spawned %+v, want only a collider of radius 5", e)
+	}
+}
+
+func TestReadDefinitionsUndefinedSheet(t *testing.T) {
+	_, err := ReadDefinitions(writeDefinitions(t, map[string]map[string]string{"archetypes": {"ghost": `{"sprite": {"sheet": "nope"}}`}}))
+	if err == nil {
+		t.Error("read an archetype whose sprite uses a sheet that isn't defined")
+	}
+}
+
+/*
+	A sheet whose picture is missing is drawn as a placeholder, so its animators play a single frame of it instead of
+	the game refusing to start
+*/
+func TestReadDefinitionsMissingSheet(t *testing.T) {
+	defs, err := ReadDefinitions(writeDefinitions(t, map[string]map[string]string{
+		"sheets": {"rings": `{"path": "sprites/missing.png"}`, "goblinrunning": `{"path": "sprites/gone.png"}`},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"ring", "goblin"} {
+		e, err := defs.Spawn(tag, pixel.ZV)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.Dir = NE
+		e.Animator.Update(e, 10)
+		if e.Sprite.Sheet != e.Animator.Sheets[0] || e.Animator.Frame != 0 || e.Animator.Len() != 1 {
+			t.Errorf("%s is showing frame %d of %d on %q, want the only frame of its placeholder", tag, e.Animator.Frame,
+				e.Animator.Len(), e.Sprite.Sheet)
+		}
+	}
+}
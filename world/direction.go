@@ -0,0 +1,88 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+	"math"
+)
+
+/*
+	Direction is one of the 8 ways an entity can face. They go clockwise starting from S.
+*/
+type Direction int
+
+const (
+	S Direction = iota
+	SW
+	W
+	NW
+	N
+	NE
+	E
+	SE
+)
+
+type dirInfo struct {
+	velocity pixel.Vec //unit vector pointing this way
+	clip     string    //suffix of the clips for this direction, sprites face left so right-facing ones reuse them
+	facing   int       //-1 if the sprite faces left, 1 if it faces right, 0 to keep facing the same way
+}
+
+// everything we need to know about each direction, worked out once instead of every frame
+var dirInfos = [...]dirInfo{
+	S:  {pixel.V(0, -1), "S", 0},
+	SW: {pixel.V(-1, -1).Unit(), "SW", -1},
+	W:  {pixel.V(-1, 0), "W", -1},
+	NW: {pixel.V(-1, 1).Unit(), "NW", -1},
+	N:  {pixel.V(0, 1), "N", 0},
+	NE: {pixel.V(1, 1).Unit(), "NW", 1},
+	E:  {pixel.V(1, 0), "W", 1},
+	SE: {pixel.V(1, -1).Unit(), "SW", 1},
+}
+
+/*
+	A unit vector pointing in this direction. Multiply by speed to get movement.
+*/
+func (d Direction) Velocity() pixel.Vec {
+	return dirInfos[d].velocity
+}
+
+/*
+	What "{dir}" in a clip name becomes for an entity facing this way, e.g. "run_{dir}" plays "run_NW"
+*/
+func (d Direction) ClipSuffix() string {
+	return dirInfos[d].clip
+}
+
+/*
+	-1 if an entity heading this way should face left, 1 if it should face right and 0 if it should keep facing
+	the way it already was
+*/
+func (d Direction) Facing() int {
+	return dirInfos[d].facing
+}
+
+/*
+	Takes a direction and rotates clockwise
+*/
+func (d Direction) Next() Direction {
+	return (d + 1) % 8
+}
+
+/*
+	The direction pointing the other way
+*/
+func (d Direction) Opposite() Direction {
+	return (d + 4) % 8
+}
+
+/*
+	Finds the direction closest to the way a vector points. The zero vector gives S.
+*/
+func FromVector(v pixel.Vec) Direction {
+	if v == pixel.ZV {
+		return S
+	}
+	//split the circle into 8 slices, with slice 0 centered on E and counting anticlockwise
+	slice := int(math.Round(math.Atan2(v.Y, v.X)/(math.Pi/4))+8) % 8
+	return [8]Direction{E, NE, N, NW, W, SW, S, SE}[slice]
+}
@@ -0,0 +1,97 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+	"math"
+	"testing"
+)
+
+var allDirs = []Direction{S, SW, W, NW, N, NE, E, SE} //clockwise from S
+
+/*
+	Every direction comes back from its own velocity, and from any length of vector pointing the same way
+*/
+func TestFromVectorEachDirection(t *testing.T) {
+	tests := []struct {
+		v    pixel.Vec
+		want Direction
+	}{
+		{pixel.V(0, -1), S},
+		{pixel.V(-1, -1), SW},
+		{pixel.V(-1, 0), W},
+		{pixel.V(-1, 1), NW},
+		{pixel.V(0, 1), N},
+		{pixel.V(1, 1), NE},
+		{pixel.V(1, 0), E},
+		{pixel.V(1, -1), SE},
+		{pixel.V(-1e-9, 0), W},
+		{pixel.V(500, 0), E},
+		{pixel.V(-1, math.Copysign(0, -1)), W}, //-0 is on the other side of the circle's seam
+	}
+	for _, test := range tests {
+		if got := FromVector(test.v); got != test.want {
+			t.Errorf("FromVector(%v) = %v, want %v", test.v, got, test.want)
+		}
+	}
+	for _, d := range allDirs {
+		if got := FromVector(d.Velocity()); got != d {
+			t.Errorf("FromVector(%v.Velocity()) = %v", d, got)
+		}
+	}
+}
+
+/*
+	Each direction covers the 45 degrees around it, so just either side of the line halfway between two neighbours
+	gives one or the other
+*/
+func TestFromVectorSectorBoundaries(t *testing.T) {
+	anticlockwise := []Direction{E, NE, N, NW, W, SW, S, SE} //from 0 degrees
+	for i, d := range anticlockwise {
+		next := anticlockwise[(i+1)%8]
+		boundary := (float64(i) + 0.5) * math.Pi / 4
+		if got := FromVector(pixel.Unit(boundary - 1e-6)); got != d {
+			t.Errorf("just before %v degrees gave %v, want %v", boundary*180/math.Pi, got, d)
+		}
+		if got := FromVector(pixel.Unit(boundary + 1e-6)); got != next {
+			t.Errorf("just after %v degrees gave %v, want %v", boundary*180/math.Pi, got, next)
+		}
+	}
+}
+
+func TestFromVectorZero(t *testing.T) {
+	if got := FromVector(pixel.ZV); got != S {
+		t.Errorf("FromVector of the zero vector = %v, want S", got)
+	}
+}
+
+func TestNext(t *testing.T) {
+	for i, d := range allDirs {
+		if want := allDirs[(i+1)%8]; d.Next() != want {
+			t.Errorf("%v.Next() = %v, want %v", d, d.Next(), want)
+		}
+	}
+	d := N
+	for i := 0; i < 8; i++ {
+		d = d.Next()
+	}
+	if d != N {
+		t.Errorf("turning 8 times from N ended up at %v", d)
+	}
+}
+
+func TestOpposite(t *testing.T) {
+	tests := []struct{ d, want Direction }{
+		{S, N}, {SW, NE}, {W, E}, {NW, SE}, {N, S}, {NE, SW}, {E, W}, {SE, NW},
+	}
+	for _, test := range tests {
+		if got := test.d.Opposite(); got != test.want {
+			t.Errorf("%v.Opposite() = %v, want %v", test.d, got, test.want)
+		}
+		if got := test.d.Opposite().Opposite(); got != test.d {
+			t.Errorf("%v.Opposite().Opposite() = %v", test.d, got)
+		}
+		if got := FromVector(test.d.Velocity().Scaled(-1)); got != test.want {
+			t.Errorf("FromVector of the way opposite %v = %v, want %v", test.d, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+)
+
+/*
+	Entity is anything that lives in the world: the player, rings, goblins, teds. What an entity does is decided by
+	which components it has, and each system in Step only looks at the entities with the components it cares about.
+*/
+type Entity struct {
+	ID        int    //unique to this entity in its world, and never handed out again even after it's removed
+	Tag       string //what kind of entity this is, e.g. "ring". Only used for debugging and level files
+	Pos       pixel.Vec
+	PrevPos   pixel.Vec //where the entity was before the last step, lets the renderer draw in between steps
+	Scale     pixel.Vec
+	Dir       Direction
+	Speed     float64
+	Moving    bool //whether the entity tried to move this step, its animator uses this to pick a clip
+	SortLayer int  //entities with a higher sort layer are drawn first
+
+	Sprite   *Sprite   //nil if the entity isn't drawn
+	Animator *Animator //nil if the sprite never changes frame
+	Collider *Collider //nil if nothing can touch the entity
+	AI       *AI       //nil if the entity doesn't think for itself
+	Pickup   *Pickup   //nil if the player can't collect the entity
+}
+
+/*
+	Sprite says which spritesheet the renderer should draw an entity from
+*/
+type Sprite struct {
+	Sheet      string  `json:"sheet"`      //name of the spritesheet in the definitions file, the animator sets it if there is one
+	SortOffset float64 `json:"sortOffset"` //added to the entity's Y position to get its sort layer, so its feet are what get sorted
+}
+
+/*
+	Collider is the circle other things bump into, placed relative to the entity's position
+*/
+type Collider struct {
+	Circle
+	Offset pixel.Vec `json:"offset"` //where the center of the circle is compared to the entity's position
+	Layer  string    `json:"layer"`  //what kind of thing it is, for triggers to tell entities apart, e.g. "player"
+	Mask   []string  `json:"mask"`   //layers of the barriers that block it. Left out for just WallLayer, empty for none
+}
+
+/*
+	Whether a barrier on the given layer stops the collider
+*/
+func (c *Collider) BlockedBy(layer string) bool {
+	if layer == "" {
+		layer = WallLayer
+	}
+	if c.Mask == nil {
+		return layer == WallLayer
+	}
+	return contains(c.Mask, layer)
+}
+
+/*
+	Whether list has s in it
+*/
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	AI lets an entity move on its own, like a goblin. It stores everything the entity needs to remember between steps.
+*/
+type AI struct {
+	follow    bool    //if goblin is following or not
+	timeSpent float64 //seconds since goblin was last stuck on a wall
+}
+
+/*
+	Pickup is something the player collects by walking into it
+*/
+type Pickup struct {
+	Score int `json:"score"` //how much collecting it adds to the score
+}
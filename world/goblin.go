@@ -0,0 +1,68 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+)
+
+const stuckFraction = 0.25 //a goblin that moves less than this much of the way it meant to is stuck on a wall
+
+/*
+	Moves the goblin where he needs to go. Updates the goblin's AI so he can keep track of where he is and what
+	he's doing between calls.
+*/
+func (w *World) goblinMovement(goblin *Entity, dt float64, playerpos pixel.Vec) {
+	goblinfo := goblin.AI
+	buffer := 10.0 //don't need to be exact, just in a range
+	//move goblin towards player
+	if goblinfo.follow {
+		if playerpos.X+buffer < goblin.Pos.X || playerpos.X+buffer < goblin.Pos.X && goblin.Pos.X < playerpos.X-buffer {
+			goblin.Scale = pixel.V(1, 1)
+			if playerpos.Y+buffer < goblin.Pos.Y || playerpos.Y+buffer < goblin.Pos.Y && goblin.Pos.Y < playerpos.Y-buffer {
+				goblin.Dir = SW
+			} else if playerpos.Y-buffer > goblin.Pos.Y || playerpos.Y-buffer > goblin.Pos.Y && goblin.Pos.Y > playerpos.X+buffer {
+				goblin.Dir = NW
+			} else {
+				goblin.Dir = W
+			}
+		} else if playerpos.X-buffer > goblin.Pos.X || playerpos.X-buffer > goblin.Pos.X && goblin.Pos.X > playerpos.X+buffer {
+			goblin.Scale = pixel.V(-1, 1)
+			if playerpos.Y+buffer < goblin.Pos.Y || playerpos.Y+buffer < goblin.Pos.Y && goblin.Pos.Y < playerpos.Y-buffer {
+				goblin.Dir = SE
+			} else if playerpos.Y-buffer > goblin.Pos.Y || playerpos.Y-buffer > goblin.Pos.Y && goblin.Pos.Y > playerpos.X+buffer {
+				goblin.Dir = NE
+			} else {
+				goblin.Dir = E
+			}
+		} else if playerpos.Y+buffer < goblin.Pos.Y || playerpos.Y+buffer < goblin.Pos.Y && goblin.Pos.Y < playerpos.Y-buffer {
+			goblin.Dir = S
+		} else if playerpos.Y-buffer > goblin.Pos.Y || playerpos.Y-buffer > goblin.Pos.Y && goblin.Pos.Y > playerpos.X+buffer {
+			goblin.Dir = N
+		}
+	}
+
+	closeEnoughToFollow := true
+
+	if distance(goblin.Pos, playerpos) > 500 { //if too far away, goblins stop following.
+		closeEnoughToFollow = false
+	}
+
+	goblin.Moving = closeEnoughToFollow
+	stuck := false
+	if closeEnoughToFollow {
+		want := w.displacement(goblin.Dir.Velocity(), goblin.Speed, dt)
+		moved := w.move(goblin, want) //calculate movement of character, sliding along walls like the player
+		stuck = moved.Len() < want.Len()*stuckFraction
+	}
+
+	if stuck { //walked straight into a wall, sliding along it gets nowhere
+		goblin.Dir = goblin.Dir.Next() //rotate 45 degrees
+		goblinfo.follow = false
+		goblinfo.timeSpent = 0 //reset time
+	} else {
+		goblinfo.timeSpent += dt //increment time since last got stuck
+	}
+	if goblinfo.timeSpent > 1.0 && closeEnoughToFollow {
+		//if youre not stuck for more than a second and youre close enough, start following again
+		goblinfo.follow = true
+	}
+}
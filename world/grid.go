@@ -0,0 +1,191 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+	"math"
+)
+
+const cellSize = 64.0 //width and height of each cell of the grid, in world pixels
+
+/*
+	grid buckets barriers and colliders into square cells, so an entity only has to be checked against what's in the
+	cells around it instead of everything in the world
+*/
+type grid struct {
+	barriers     map[cell][]int //index in Barriers of every barrier that crosses each cell
+	barrierCount int            //how many of the world's barriers have been bucketed
+	marks        []int          //query each barrier was last found by, so one crossing several cells is only given back once
+	query        int
+
+	entities map[cell][]*Entity
+	spans    map[*Entity]span //cells each entity's collider was covering when it was last placed
+}
+
+type cell struct {
+	X, Y int
+}
+
+/*
+	span is a rectangle of cells, from min to max inclusive
+*/
+type span struct {
+	Min, Max cell
+}
+
+func newGrid() *grid {
+	return &grid{barriers: map[cell][]int{}, entities: map[cell][]*Entity{}, spans: map[*Entity]span{}}
+}
+
+/*
+	The cells a circle's bounding box covers
+*/
+func circleSpan(c Circle) span {
+	return span{cellAt(c.Center.Sub(pixel.V(c.Radius, c.Radius))), cellAt(c.Center.Add(pixel.V(c.Radius, c.Radius)))}
+}
+
+/*
+	The cells a rectangle covers
+*/
+func rectSpan(r pixel.Rect) span {
+	return span{cellAt(r.Min), cellAt(r.Max)}
+}
+
+/*
+	The cell a point is in
+*/
+func cellAt(p pixel.Vec) cell {
+	return cell{int(math.Floor(p.X / cellSize)), int(math.Floor(p.Y / cellSize))}
+}
+
+/*
+	The grid with every barrier of the world in it. Barriers are only ever added to a world, so any added since the
+	last time are bucketed now.
+*/
+func (w *World) index() *grid {
+	if w.grid == nil {
+		w.grid = newGrid()
+	}
+	g := w.grid
+	for ; g.barrierCount < len(w.Barriers); g.barrierCount++ {
+		g.addBarrier(g.barrierCount, w.Barriers[g.barrierCount])
+		g.marks = append(g.marks, 0)
+	}
+	return g
+}
+
+/*
+	Puts a barrier into every cell it crosses
+*/
+func (g *grid) addBarrier(i int, line Line) {
+	halfDiagonal := cellSize * math.Sqrt2 / 2
+	bounds := span{cellAt(pixel.V(math.Min(line.A.X, line.B.X), math.Min(line.A.Y, line.B.Y))),
+		cellAt(pixel.V(math.Max(line.A.X, line.B.X), math.Max(line.A.Y, line.B.Y)))}
+	for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+			center := pixel.V((float64(x)+0.5)*cellSize, (float64(y)+0.5)*cellSize)
+			if distance(closestPoint(line, center), center) <= halfDiagonal { //a slanted barrier misses most of its box
+				g.barriers[cell{x, y}] = append(g.barriers[cell{x, y}], i)
+			}
+		}
+	}
+}
+
+/*
+	Appends the index of every barrier in the cells a circle covers to found, each one once
+*/
+func (g *grid) barriersNear(c Circle, found []int) []int {
+	g.query++
+	s := circleSpan(c)
+	for x := s.Min.X; x <= s.Max.X; x++ {
+		for y := s.Min.Y; y <= s.Max.Y; y++ {
+			for _, i := range g.barriers[cell{x, y}] {
+				if g.marks[i] != g.query {
+					g.marks[i] = g.query
+					found = append(found, i)
+				}
+			}
+		}
+	}
+	return found
+}
+
+/*
+	Moves an entity to the cells its collider now covers. Nothing changes if it's still in the same ones, which is
+	most of the time.
+*/
+func (g *grid) place(e *Entity) {
+	s := circleSpan(e.Collider.Circle)
+	old, ok := g.spans[e]
+	if ok && old == s {
+		return
+	}
+	if ok {
+		g.remove(e)
+	}
+	g.spans[e] = s
+	for x := s.Min.X; x <= s.Max.X; x++ {
+		for y := s.Min.Y; y <= s.Max.Y; y++ {
+			g.entities[cell{x, y}] = append(g.entities[cell{x, y}], e)
+		}
+	}
+}
+
+/*
+	Takes an entity out of every cell it's in
+*/
+func (g *grid) remove(e *Entity) {
+	s, ok := g.spans[e]
+	if !ok {
+		return
+	}
+	delete(g.spans, e)
+	for x := s.Min.X; x <= s.Max.X; x++ {
+		for y := s.Min.Y; y <= s.Max.Y; y++ {
+			bucket := g.entities[cell{x, y}]
+			for i, other := range bucket {
+				if other == e {
+					bucket[i] = bucket[len(bucket)-1]
+					bucket = bucket[:len(bucket)-1]
+					break
+				}
+			}
+			if len(bucket) == 0 {
+				delete(g.entities, cell{x, y})
+			} else {
+				g.entities[cell{x, y}] = bucket
+			}
+		}
+	}
+}
+
+/*
+	Appends every entity in the cells a circle covers to found, each one once
+*/
+func (g *grid) entitiesNear(c Circle, found []*Entity) []*Entity {
+	return g.entitiesIn(circleSpan(c), found)
+}
+
+/*
+	Appends every entity in a span of cells to found, each one once
+*/
+func (g *grid) entitiesIn(s span, found []*Entity) []*Entity {
+	for x := s.Min.X; x <= s.Max.X; x++ {
+		for y := s.Min.Y; y <= s.Max.Y; y++ {
+			for _, e := range g.entities[cell{x, y}] {
+				//an entity covering several of the cells is only taken from the first one it shares with the span
+				es := g.spans[e]
+				if x == maxInt(es.Min.X, s.Min.X) && y == maxInt(es.Min.Y, s.Min.Y) {
+					found = append(found, e)
+				}
+			}
+		}
+	}
+	return found
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
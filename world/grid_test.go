@@ -0,0 +1,164 @@
+package world
+
+import (
+	"GoGui/level"
+	"fmt"
+	"github.com/faiface/pixel"
+	"math/rand"
+	"testing"
+)
+
+/*
+	Every barrier a circle overlaps is among the ones the grid finds near it
+*/
+func TestGridFindsEveryContact(t *testing.T) {
+	w := &World{Barriers: layoutBarriers(t)}
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 20000; n++ {
+		circ := Circle{pixel.V(rng.Float64()*1600-150, rng.Float64()*1200-100), 1 + rng.Float64()*80}
+		near := map[int]bool{}
+		for _, i := range w.index().barriersNear(circ, nil) {
+			if near[i] {
+				t.Fatalf("%v: barrier %d was found twice", circ, i)
+			}
+			near[i] = true
+		}
+		for i, line := range w.Barriers {
+			if _, hit := circ.Collide(line); hit && !near[i] {
+				t.Fatalf("%v overlaps barrier %d %v, but the grid didn't find it", circ, i, line)
+			}
+		}
+	}
+}
+
+/*
+	Barriers added after the grid is built are found too
+*/
+func TestGridAddedBarriers(t *testing.T) {
+	w := &World{}
+	circ := Circle{pixel.V(500, 500), 10}
+	if len(w.index().barriersNear(circ, nil)) != 0 {
+		t.Fatal("found a barrier in an empty world")
+	}
+	w.Barriers = append(w.Barriers, Line{A: pixel.V(400, 505), B: pixel.V(600, 505)})
+	if found := w.index().barriersNear(circ, nil); len(found) != 1 || found[0] != 0 {
+		t.Fatalf("found %v, want the barrier that was added", found)
+	}
+}
+
+/*
+	An entity is found where its collider is now, not where it used to be, and not at all once it's removed
+*/
+func TestGridMovesEntities(t *testing.T) {
+	g := newGrid()
+	e := &Entity{Collider: &Collider{Circle: Circle{pixel.V(10, 10), 5}}}
+	g.place(e)
+	here := Circle{pixel.V(10, 10), 1}
+	there := Circle{pixel.V(1000, -1000), 1}
+	if found := g.entitiesNear(here, nil); len(found) != 1 || found[0] != e {
+		t.Fatalf("found %v where the entity was placed", found)
+	}
+
+	e.Collider.Center = pixel.V(1000, -1000)
+	g.place(e)
+	if found := g.entitiesNear(here, nil); len(found) != 0 {
+		t.Errorf("found %v where the entity used to be", found)
+	}
+	if found := g.entitiesNear(there, nil); len(found) != 1 || found[0] != e {
+		t.Errorf("found %v where the entity moved to", found)
+	}
+
+	e.Collider.Center = pixel.V(cellSize, cellSize) //right on a corner, so it's in four cells
+	g.place(e)
+	if found := g.entitiesNear(Circle{pixel.V(cellSize, cellSize), cellSize}, nil); len(found) != 1 {
+		t.Errorf("found %v, want the entity just once", found)
+	}
+
+	g.remove(e)
+	if found := g.entitiesNear(Circle{pixel.V(cellSize, cellSize), cellSize}, nil); len(found) != 0 {
+		t.Errorf("found %v after the entity was removed", found)
+	}
+	if len(g.entities) != 0 {
+		t.Errorf("%d cells are left over after the entity was removed", len(g.entities))
+	}
+}
+
+/*
+	A level the size of a few hundred screens, scattered with barriers, rings and goblins around the player
+*/
+func syntheticLevel(barriers int, items int) *level.Level {
+	rng := rand.New(rand.NewSource(1))
+	size := 20000.0
+	lvl := level.New()
+	lvl.Spawn = pixel.V(size/2, size/2)
+	random := func() pixel.Vec {
+		return pixel.V(rng.Float64()*size, rng.Float64()*size)
+	}
+	for i := 0; i < barriers; i++ {
+		a := random()
+		lvl.Barriers = append(lvl.Barriers, level.Barrier{A: a, B: a.Add(pixel.V(rng.Float64()*300-150, rng.Float64()*300-150))})
+	}
+	for i := 0; i < items; i++ {
+		tag := "ring"
+		if i%4 == 0 {
+			tag = "goblin"
+		}
+		lvl.Items = append(lvl.Items, level.Item{Tag: tag, Pos: lvl.Spawn.Add(random().Sub(lvl.Spawn).Scaled(0.1))}) //crowded near the player
+	}
+	return lvl
+}
+
+func syntheticWorld(b *testing.B, barriers int, items int) *World {
+	defs, err := ReadDefinitions("../entities.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	w := New(pixel.ZV, defs)
+	w.LoadLevel(syntheticLevel(barriers, items))
+	return w
+}
+
+/*
+	What a whole step costs as levels grow, with the player running in circles
+*/
+func BenchmarkStep(b *testing.B) {
+	for _, size := range []struct{ barriers, items int }{{200, 100}, {5000, 1000}, {50000, 4000}} {
+		b.Run(fmt.Sprintf("barriers=%d,items=%d", size.barriers, size.items), func(b *testing.B) {
+			w := syntheticWorld(b, size.barriers, size.items)
+			dirs := []Direction{N, NE, E, SE, S, SW, W, NW}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				v := dirs[(i/30)%len(dirs)].Velocity()
+				w.Step(Tick, Controls{MoveX: v.X, MoveY: v.Y})
+			}
+		})
+	}
+}
+
+/*
+	Resolving one entity's collisions through the grid, against checking every barrier in the level
+*/
+func BenchmarkResolve(b *testing.B) {
+	w := syntheticWorld(b, 50000, 0)
+	e := w.Player
+	start := e.Pos
+	b.Run("grid", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			e.Pos = start
+			e.Collider.Center = e.Pos.Add(e.Collider.Offset)
+			w.resolveCollisions(e)
+		}
+	})
+	b.Run("every barrier", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			e.Pos = start
+			e.Collider.Center = e.Pos.Add(e.Collider.Offset)
+			for _, line := range w.Barriers {
+				if contact, ok := e.Collider.Collide(line); ok {
+					e.Pos = e.Pos.Add(contact.Normal.Scaled(contact.Depth))
+					e.Collider.Center = e.Pos.Add(e.Collider.Offset)
+				}
+			}
+		}
+	})
+}
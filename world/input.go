@@ -0,0 +1,36 @@
+package world
+
+/*
+	Input is anything that can tell the world what the player is doing: the keyboard, a gamepad, a replay file or a
+	script in a test
+*/
+type Input interface {
+	Update()            //called once every frame, before the world steps
+	Controls() Controls //called once for every step of the world
+}
+
+/*
+	Script is an Input that plays back a list of controls, one per step. Once it runs out the player stands still.
+*/
+type Script struct {
+	Steps []Controls
+	next  int //index of the next step to hand out
+}
+
+func (s *Script) Update() {}
+
+func (s *Script) Controls() Controls {
+	if s.Done() {
+		return Controls{}
+	}
+	c := s.Steps[s.next]
+	s.next++
+	return c
+}
+
+/*
+	Returns true once every step of the script has been handed out
+*/
+func (s *Script) Done() bool {
+	return s.next >= len(s.Steps)
+}
@@ -0,0 +1,38 @@
+package world
+
+import (
+	"GoGui/level"
+	"github.com/faiface/pixel"
+	"log"
+)
+
+/*
+	Fills the world with a level's barriers, triggers and items, and moves the player to its spawn point
+*/
+func (w *World) LoadLevel(lvl *level.Level) {
+	w.YScale = lvl.YScale
+	w.Goal = lvl.Goal
+	w.Origin = lvl.Spawn
+	w.Player.Pos = lvl.Spawn
+	w.Player.PrevPos = lvl.Spawn
+
+	for _, bar := range lvl.Barriers {
+		w.Barriers = append(w.Barriers, Line{bar.A, bar.B, bar.Layer})
+	}
+	for _, t := range lvl.Triggers {
+		w.Triggers = append(w.Triggers, &Trigger{Name: t.Name, Rect: pixel.Rect{Min: t.Min, Max: t.Max}, Mask: t.Mask})
+	}
+
+	for _, item := range lvl.Items {
+		e, err := w.defs.Spawn(item.Tag, item.Pos) //the definitions know what each kind of item is made of
+		if err != nil {
+			log.Println("skipping item in level: " + err.Error())
+			continue
+		}
+		if e.Animator != nil { //so items of the same kind don't all spin in step
+			e.Animator.Seek(w.rng.Intn(e.Animator.Len()))
+		}
+		w.Add(e)
+	}
+	w.placeColliders()
+}
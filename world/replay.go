@@ -0,0 +1,148 @@
+package world
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/*
+	Writes the controls used for every step of the world to a replay file, one step per line
+*/
+type Recorder struct {
+	file   *os.File
+	writer *bufio.Writer
+}
+
+/*
+	Replay is a recorded run: the seed and level it was played with, and the controls of every step
+*/
+type Replay struct {
+	Seed  int64
+	Level string //the level file, or the manifest of levels, the run was played on
+	Steps []Controls
+}
+
+const replayHeader = "replay" //first value of the first line of every replay file
+
+/*
+	Creates (or overwrites) a replay file and gets it ready for recording. The seed and level go at the top, since
+	the controls only play out the same way again with both of them.
+*/
+func NewRecorder(path string, seed int64, level string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{file, bufio.NewWriter(file)}
+	if _, err := fmt.Fprintf(r.writer, "%s,%d,%s,\n", replayHeader, seed, level); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+	Adds the controls of a single step to the end of the replay
+*/
+func (r *Recorder) Record(c Controls) error {
+	//same style as our level files, every value is followed by a comma
+	_, err := fmt.Fprintf(r.writer, "%g,%g,%s,%s,\n",
+		c.MoveX, c.MoveY, replayBit(c.Respawn), replayBit(c.ToggleDebug))
+	return err
+}
+
+/*
+	Finishes writing the replay file
+*/
+func (r *Recorder) Close() error {
+	if err := r.writer.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+/*
+	Reads in a replay file made by a Recorder. The controls come back in the order they were recorded.
+*/
+func ReadReplay(path string) (*Replay, error) {
+	file, err := os.Open(path) //open to read
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	replay := &Replay{}
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: the replay is empty", path)
+	}
+	header := strings.Split(scanner.Text(), ",")
+	if len(header) < 4 || header[0] != replayHeader {
+		return nil, fmt.Errorf("%s:1: expected the seed and level the replay was recorded with", path)
+	}
+	replay.Seed, err = strconv.ParseInt(header[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s:1: bad seed: %v", path, err)
+	}
+	replay.Level = strings.Join(header[2:len(header)-1], ",") //the path could have commas of its own
+
+	lineNum := 1
+	for scanner.Scan() {
+		lineNum++
+		lineElems := strings.Split(scanner.Text(), ",") //split on commas
+		if len(lineElems) != 5 {
+			return nil, fmt.Errorf("%s:%d: expected 4 values, got %d", path, lineNum, len(lineElems)-1)
+		}
+		moveX, err := strconv.ParseFloat(lineElems[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: bad MoveX: %v", path, lineNum, err)
+		}
+		moveY, err := strconv.ParseFloat(lineElems[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: bad MoveY: %v", path, lineNum, err)
+		}
+		replay.Steps = append(replay.Steps, Controls{
+			MoveX:       moveX,
+			MoveY:       moveY,
+			Respawn:     lineElems[2] == "1",
+			ToggleDebug: lineElems[3] == "1",
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+/*
+	Makes sure the replay is being played with the seed and level it was recorded with, otherwise the same controls
+	would play out differently
+*/
+func (r *Replay) Check(seed int64, level string) error {
+	if seed != r.Seed {
+		return fmt.Errorf("the replay was recorded with -seed %d, not %d", r.Seed, seed)
+	}
+	if filepath.Clean(level) != filepath.Clean(r.Level) {
+		return fmt.Errorf("the replay was recorded on %s, not %s", r.Level, level)
+	}
+	return nil
+}
+
+/*
+	Turns a bool into the 1 or 0 written in replay files
+*/
+func replayBit(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
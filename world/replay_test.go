@@ -0,0 +1,66 @@
+package world
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+/*
+	A recorded run played back from its replay file, in a fresh world, ends up exactly where the recording did
+*/
+func TestReplayRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.txt")
+	rec, err := NewRecorder(path, 7, "../level.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := playThrough(t, 7, "../level.json", &Script{Steps: testScript()}, rec)
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := ReadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.Seed != 7 || replay.Level != "../level.json" {
+		t.Errorf("read back seed %d and level %q, want 7 and ../level.json", replay.Seed, replay.Level)
+	}
+	if !reflect.DeepEqual(replay.Steps, testScript()) {
+		t.Errorf("read back %d steps that don't match the %d recorded", len(replay.Steps), len(testScript()))
+	}
+	if err := replay.Check(7, "../level.json"); err != nil {
+		t.Error(err)
+	}
+
+	played := playThrough(t, replay.Seed, replay.Level, &Script{Steps: replay.Steps}, nil)
+	want, wantScore := snapshot(recorded)
+	got, gotScore := snapshot(played)
+	if !reflect.DeepEqual(got, want) || gotScore != wantScore {
+		t.Errorf("the replay finished with score %d and %+v, the recording with score %d and %+v", gotScore, got,
+			wantScore, want)
+	}
+}
+
+/*
+	A replay won't play with a seed or level other than the ones it was recorded with
+*/
+func TestReplayCheck(t *testing.T) {
+	replay := &Replay{Seed: 7, Level: "levels/cave.json"}
+	tests := []struct {
+		seed  int64
+		level string
+		ok    bool
+	}{
+		{7, "levels/cave.json", true},
+		{7, "./levels/cave.json", true},
+		{8, "levels/cave.json", false},
+		{7, "levels.json", false},
+	}
+	for _, test := range tests {
+		if err := replay.Check(test.seed, test.level); (err == nil) != test.ok {
+			t.Errorf("seed %d on %s: got %v, want ok = %v", test.seed, test.level, err, test.ok)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+	"sort"
+)
+
+/*
+	Trigger is an area that doesn't block anything, but tells the world's OnTrigger whenever an entity goes in, stays
+	in or comes out of it
+*/
+type Trigger struct {
+	Name string
+	Rect pixel.Rect
+	Mask []string //layers of the colliders that set it off, none for every collider
+
+	inside []*Entity //entities that were in it after the last step, sorted by ID
+	next   []*Entity //entities found in it this step, swapped with inside once every event has been sent
+}
+
+/*
+	TriggerEvent is what an entity did with a trigger during a step
+*/
+type TriggerEvent int
+
+const (
+	TriggerEnter TriggerEvent = iota //the entity has just come into the trigger
+	TriggerStay                      //the entity was in the trigger last step and still is
+	TriggerExit                      //the entity has just left the trigger, or the world
+)
+
+func (ev TriggerEvent) String() string {
+	switch ev {
+	case TriggerEnter:
+		return "enter"
+	case TriggerStay:
+		return "stay"
+	case TriggerExit:
+		return "exit"
+	}
+	return "unknown"
+}
+
+/*
+	Whether a collider is one of the kinds that set the trigger off
+*/
+func (t *Trigger) Matches(c *Collider) bool {
+	return len(t.Mask) == 0 || contains(t.Mask, c.Layer)
+}
+
+/*
+	Finds every entity in each trigger and sends OnTrigger an event for it, plus an exit for every entity that was
+	inside last step and isn't anymore
+*/
+func (w *World) checkTriggers() {
+	g := w.index()
+	for _, t := range w.Triggers {
+		t.next = t.next[:0]
+		w.nearEntities = g.entitiesIn(rectSpan(t.Rect), w.nearEntities[:0])
+		for _, e := range w.nearEntities {
+			if e.Collider == nil || !t.Matches(e.Collider) || !e.Collider.Overlaps(t.Rect) {
+				continue
+			}
+			t.next = append(t.next, e)
+		}
+		sort.Slice(t.next, func(i, j int) bool { //so events come in the same order every time, and inside can search it
+			return t.next[i].ID < t.next[j].ID
+		})
+
+		for _, e := range t.next {
+			if inside(t.inside, e) {
+				w.trigger(t, e, TriggerStay)
+			} else {
+				w.trigger(t, e, TriggerEnter)
+			}
+		}
+		for _, e := range t.inside {
+			if !inside(t.next, e) {
+				w.trigger(t, e, TriggerExit)
+			}
+		}
+		t.inside, t.next = t.next, t.inside
+	}
+}
+
+/*
+	Sends OnTrigger an event, if anything is listening
+*/
+func (w *World) trigger(t *Trigger, e *Entity, event TriggerEvent) {
+	if w.OnTrigger != nil {
+		w.OnTrigger(t, e, event)
+	}
+}
+
+/*
+	Whether an entity is in a list of them sorted by ID
+*/
+func inside(entities []*Entity, e *Entity) bool {
+	i := sort.Search(len(entities), func(i int) bool { return entities[i].ID >= e.ID })
+	return i < len(entities) && entities[i] == e
+}
@@ -0,0 +1,52 @@
+package world
+
+import (
+	"github.com/faiface/pixel"
+	"reflect"
+	"testing"
+)
+
+/*
+	Walking an entity through a trigger sends enter, then stay, then exit, and only for the layers in its mask
+*/
+func TestTriggerEvents(t *testing.T) {
+	var got []string
+	w := &World{
+		Triggers: []*Trigger{{Name: "gate", Rect: pixel.R(100, -50, 200, 50), Mask: []string{"player"}}},
+		OnTrigger: func(t *Trigger, e *Entity, event TriggerEvent) {
+			got = append(got, t.Name+" "+e.Tag+" "+event.String())
+		},
+	}
+	player := w.Add(&Entity{Tag: "player", Collider: &Collider{Circle: Circle{Radius: 10}, Layer: "player"}})
+	goblin := w.Add(&Entity{Tag: "goblin", Collider: &Collider{Circle: Circle{Radius: 10}, Layer: "goblin"}})
+
+	for _, x := range []float64{0, 95, 150, 205, 215} {
+		player.Pos = pixel.V(x, 0)
+		goblin.Pos = pixel.V(x, 0)
+		w.placeColliders()
+		w.checkTriggers()
+	}
+	want := []string{"gate player enter", "gate player stay", "gate player stay", "gate player exit"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got events %q, want %q", got, want)
+	}
+}
+
+/*
+	An entity taken out of the world while it's in a trigger leaves it
+*/
+func TestTriggerRemoved(t *testing.T) {
+	var got []TriggerEvent
+	w := &World{
+		Triggers:  []*Trigger{{Name: "pit", Rect: pixel.R(-10, -10, 10, 10)}},
+		OnTrigger: func(t *Trigger, e *Entity, event TriggerEvent) { got = append(got, event) },
+	}
+	ring := w.Add(&Entity{Collider: &Collider{Circle: Circle{Radius: 5}}})
+	w.placeColliders()
+	w.checkTriggers()
+	w.remove(ring)
+	w.checkTriggers()
+	if want := []TriggerEvent{TriggerEnter, TriggerExit}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
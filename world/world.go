@@ -0,0 +1,229 @@
+/*
+	Package world holds every rule of the game: movement, collisions, goblin brains and scoring.
+	Nothing in here touches a window, so the simulation can run anywhere, display or not.
+*/
+package world
+
+import (
+	"GoGui/level"
+	"github.com/faiface/pixel"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type Circle struct {
+	Center pixel.Vec `json:"-"`
+	Radius float64   `json:"radius"`
+}
+
+type Line struct {
+	A     pixel.Vec
+	B     pixel.Vec
+	Layer string //which colliders it blocks, "" for WallLayer
+}
+
+const WallLayer = "wall" //layer of barriers that block every collider without a mask
+
+const TickRate = 60 //how many times per second the world steps
+
+const Tick = 1.0 / TickRate //seconds of game time that pass in a single step
+
+/*
+	Controls are the actions the player is taking during a single step of the world
+*/
+type Controls struct {
+	MoveX   float64 //less than 0 to move left, more than 0 to move right
+	MoveY   float64 //less than 0 to move down, more than 0 to move up
+	Respawn bool
+
+	ToggleDebug bool //the world doesn't use this, but it's kept with the rest so replays show exactly what the player saw
+}
+
+type World struct {
+	Entities []*Entity  //everything in the world, in the order it should be drawn
+	Barriers []Line     //all collider barriers to keep entities from leaving the play space. Only ever added to
+	Triggers []*Trigger //areas that tell OnTrigger when entities go in and out of them
+	Score    int        //number of rings the player has collected
+
+	Player *Entity //the player is also in Entities
+
+	Origin pixel.Vec  //where the player starts and respawns, normally the center of the window
+	YScale float64    //squashes vertical movement to suit the map's isometric look, 1 for no squashing
+	Goal   level.Goal //what finishes the level
+
+	OnTrigger func(t *Trigger, e *Entity, event TriggerEvent) //called for every trigger event, nil for nothing
+
+	defs   *Definitions //what every kind of entity is made of
+	rng    *rand.Rand   //every random choice the world makes comes from here, so the same seed plays out the same
+	nextID int          //ID the next entity added will get
+
+	grid         *grid //barriers and colliders bucketed by where they are, nil until index first builds it
+	nearBarriers []int //reused for every lookup in the grid, so stepping doesn't allocate
+	nearEntities []*Entity
+}
+
+/*
+	Creates an empty world with the player standing at origin
+*/
+func New(origin pixel.Vec, defs *Definitions) *World {
+	w := &World{
+		Origin: origin,
+		YScale: 1,
+		defs:   defs,
+		rng:    rand.New(rand.NewSource(1)),
+	}
+	player, err := defs.Spawn("player", origin)
+	if err != nil {
+		panic(err) //ReadDefinitions makes sure there's always a player
+	}
+	w.Player = w.Add(player)
+	return w
+}
+
+/*
+	Restarts the world's random numbers from the given seed. Call this before loading a level.
+*/
+func (w *World) Seed(seed int64) {
+	w.rng = rand.New(rand.NewSource(seed))
+}
+
+/*
+	Puts an entity into the world and gives it its ID
+*/
+func (w *World) Add(e *Entity) *Entity {
+	w.nextID++
+	e.ID = w.nextID
+	w.Entities = append(w.Entities, e)
+	return e
+}
+
+/*
+	Finds the entity with the given ID, or nil if it isn't in the world anymore
+*/
+func (w *World) Get(id int) *Entity {
+	for _, e := range w.Entities {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+/*
+	Advances the world by dt seconds using the given controls. To get the same outcome every time the same
+	controls are given, dt should always be Tick.
+*/
+func (w *World) Step(dt float64, in Controls) {
+	//remember where everything was so the renderer can draw between this step and the next
+	for _, e := range w.Entities {
+		e.PrevPos = e.Pos
+	}
+
+	w.movePlayer(dt, in)
+	w.moveAIs(dt)
+	w.animate(dt)
+	w.placeColliders()
+	w.collectPickups()
+	w.checkTriggers()
+	w.sortEntities()
+}
+
+/*
+	Whether the player has done what the level's goal asks of them
+*/
+func (w *World) Complete() bool {
+	switch w.Goal {
+	case level.GoalPickups:
+		for _, e := range w.Entities {
+			if e.Pickup != nil {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+/*
+	Moves the player according to the controls, sliding along any walls in the way. The player's animator sees whether
+	they moved and picks the clip.
+*/
+func (w *World) movePlayer(dt float64, in Controls) {
+	player := w.Player
+
+	move := pixel.V(in.MoveX, in.MoveY)
+	player.Moving = move != pixel.ZV
+	if player.Moving {
+		player.Dir = FromVector(move) //set player look direction
+		if facing := player.Dir.Facing(); facing != 0 {
+			player.Scale = pixel.V(float64(-facing), 1) //the sprite faces left, so flip it to face right
+		}
+		throttle := math.Min(move.Len(), 1) //a gamepad stick that's only pushed part way moves the player slower
+		w.move(player, w.displacement(move.Unit(), player.Speed*throttle, dt))
+	}
+
+	if in.Respawn { //respawn at the beginning
+		player.Pos = w.Origin
+		player.PrevPos = player.Pos //teleport instead of sliding across the map
+	}
+}
+
+/*
+	Lets every entity with a brain decide where to go
+*/
+func (w *World) moveAIs(dt float64) {
+	for _, e := range w.Entities {
+		if e.AI == nil {
+			continue
+		}
+		w.goblinMovement(e, dt, w.Player.Pos) //move goblin, update goblin's knowledge
+	}
+}
+
+/*
+	Moves every animated entity on to its next frame when it's time
+*/
+func (w *World) animate(dt float64) {
+	for _, e := range w.Entities {
+		if e.Animator != nil {
+			e.Animator.Update(e, dt)
+		}
+	}
+}
+
+/*
+	Moves every collider to where its entity now is, and into the cells of the grid it now covers
+*/
+func (w *World) placeColliders() {
+	g := w.index()
+	for _, e := range w.Entities {
+		if e.Collider != nil {
+			e.Collider.Center = e.Pos.Add(e.Collider.Offset)
+			g.place(e)
+		}
+	}
+}
+
+/*
+	Sorts entities so the ones further back get drawn first
+*/
+func (w *World) sortEntities() {
+	for _, e := range w.Entities {
+		if e.Sprite != nil {
+			e.SortLayer = int(e.Pos.Y + e.Sprite.SortOffset)
+		}
+	}
+	sort.SliceStable(w.Entities, func(j, i int) bool {
+		return w.Entities[i].SortLayer < w.Entities[j].SortLayer
+	})
+}
+
+/*
+	How far something heading in a direction at a speed moves in dt seconds. Vertical movement is squashed by the
+	map's YScale.
+*/
+func (w *World) displacement(dir pixel.Vec, speed float64, dt float64) pixel.Vec {
+	d := dir.Scaled(speed * dt)
+	return pixel.V(d.X, d.Y*w.YScale)
+}
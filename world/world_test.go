@@ -0,0 +1,186 @@
+package world
+
+import (
+	"GoGui/level"
+	"github.com/faiface/pixel"
+	"math"
+	"reflect"
+	"testing"
+)
+
+/*
+	A few seconds of running around the default level, with a respawn part way through
+*/
+func testScript() []Controls {
+	var steps []Controls
+	for i, dir := range []Direction{N, NE, E, SE, S, SW, W, NW, N, E} {
+		v := dir.Velocity()
+		for j := 0; j < 40; j++ {
+			steps = append(steps, Controls{MoveX: v.X, MoveY: v.Y, Respawn: i == 6 && j == 0})
+		}
+		steps = append(steps, make([]Controls, 15)...) //stand still for a moment
+	}
+	return steps
+}
+
+/*
+	Sets up a world the way the game does, from a seed and a level file, and plays an input through it until it's done.
+	Every step's controls are recorded too, unless rec is nil.
+*/
+func playThrough(t *testing.T, seed int64, levelPath string, in *Script, rec *Recorder) *World {
+	defs, err := ReadDefinitions("../entities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lvl, err := level.Load(levelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := New(lvl.Spawn, defs)
+	w.Seed(seed)
+	w.LoadLevel(lvl)
+	for !in.Done() {
+		in.Update()
+		controls := in.Controls()
+		if rec != nil {
+			if err := rec.Record(controls); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w.Step(Tick, controls)
+	}
+	return w
+}
+
+/*
+	entityState is everything about an entity that a run could get wrong
+*/
+type entityState struct {
+	ID     int
+	Tag    string
+	Pos    pixel.Vec
+	Dir    Direction
+	Moving bool
+	State  string
+	Frame  int
+}
+
+/*
+	What's in the world, in draw order, and the score
+*/
+func snapshot(w *World) ([]entityState, int) {
+	var states []entityState
+	for _, e := range w.Entities {
+		s := entityState{ID: e.ID, Tag: e.Tag, Pos: e.Pos, Dir: e.Dir, Moving: e.Moving}
+		if e.Animator != nil {
+			s.State, s.Frame = e.Animator.State, e.Animator.Frame
+		}
+		states = append(states, s)
+	}
+	return states, w.Score
+}
+
+/*
+	The same seed, level and controls always play out exactly the same
+*/
+func TestStepDeterministic(t *testing.T) {
+	w := playThrough(t, 42, "../level.json", &Script{Steps: testScript()}, nil)
+	if w.Player.Pos == w.Origin || len(w.Entities) == 1 {
+		t.Fatal("nothing happened, the player never moved or the level has no items")
+	}
+	first, firstScore := snapshot(w)
+	second, secondScore := snapshot(playThrough(t, 42, "../level.json", &Script{Steps: testScript()}, nil))
+	if firstScore != secondScore {
+		t.Errorf("scored %d the first time and %d the second", firstScore, secondScore)
+	}
+	if !reflect.DeepEqual(first, second) {
+		for i := 0; i < len(first) && i < len(second); i++ {
+			if first[i] != second[i] {
+				t.Fatalf("entity %d of %d differs: %+v the first time, %+v the second", i, len(first), first[i], second[i])
+			}
+		}
+		t.Fatalf("ended up with %d entities the first time and %d the second", len(first), len(second))
+	}
+}
+
+/*
+	Every goblin has a brain of its own, so one walking into a wall doesn't stop another from following the player,
+	and each can still be found by its ID however the entities get sorted
+*/
+func TestGoblinsThinkAlone(t *testing.T) {
+	defs, err := ReadDefinitions("../entities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := New(pixel.ZV, defs)
+	w.Barriers = append(w.Barriers, Line{A: pixel.V(180, -300), B: pixel.V(180, 300)}) //between the player and one goblin
+	spawn := func(pos pixel.Vec) int {
+		goblin, err := defs.Spawn("goblin", pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return w.Add(goblin).ID
+	}
+	walled, open := spawn(pixel.V(200, 0)), spawn(pixel.V(-200, 360))
+
+	steps := 10
+	for i := 0; i < steps; i++ {
+		w.Step(Tick, Controls{})
+	}
+	stuck, free := w.Get(walled), w.Get(open)
+	if stuck == nil || free == nil || stuck.ID != walled || free.ID != open {
+		t.Fatalf("looking up goblins %d and %d found %v and %v", walled, open, stuck, free)
+	}
+	if stuck.AI.follow || stuck.AI.timeSpent >= float64(steps)*Tick {
+		t.Errorf("the goblin behind the wall is following = %v, %v seconds after getting stuck, want it to have got stuck",
+			stuck.AI.follow, stuck.AI.timeSpent)
+	}
+	if want := 10 + float64(steps)*Tick; !free.AI.follow || math.Abs(free.AI.timeSpent-want) > 1e-9 {
+		t.Errorf("the goblin in the open is following = %v, %v seconds since it was stuck, want it following for %v",
+			free.AI.follow, free.AI.timeSpent, want)
+	}
+	if free.Pos.Sub(pixel.V(-200, 360)).Len() < float64(steps)*free.Speed*Tick*0.9 {
+		t.Errorf("the goblin in the open only got to %v", free.Pos)
+	}
+	if w.Get(1000) != nil {
+		t.Error("found an entity with an ID that was never handed out")
+	}
+}
+
+/*
+	A level with the pickups goal is complete once the player has collected its one ring, and a level without a goal
+	never is, even with nothing left to pick up
+*/
+func TestComplete(t *testing.T) {
+	defs, err := ReadDefinitions("../entities.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		goal level.Goal
+		want bool
+	}{
+		{"pickups", level.GoalPickups, true},
+		{"no goal", level.GoalNone, false},
+	}
+	for _, test := range tests {
+		lvl := level.New()
+		lvl.Goal = test.goal
+		lvl.Items = append(lvl.Items, level.Item{Tag: "ring", Pos: pixel.V(100, -20)}) //level with the player's feet
+		w := New(pixel.ZV, defs)
+		w.LoadLevel(lvl)
+		if w.Complete() {
+			t.Errorf("%s: complete before the ring was collected", test.name)
+		}
+		for i := 0; i < TickRate && w.Score == 0; i++ { //walk right onto the ring
+			w.Step(Tick, Controls{MoveX: 1})
+		}
+		if w.Score != 1 {
+			t.Fatalf("%s: walked to %v without collecting the ring", test.name, w.Player.Pos)
+		}
+		if got := w.Complete(); got != test.want {
+			t.Errorf("%s: complete = %v once the ring was collected, want %v", test.name, got, test.want)
+		}
+	}
+}